@@ -2,7 +2,11 @@ package clitest
 
 import (
 	"encoding/hex"
+	"fmt"
 	"github.com/stretchr/testify/suite"
+	rtypes "github.com/summa-tx/relays/golang/x/relay/types"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -284,6 +288,14 @@ func (suite *UtilsSuite) TestRelayCLIQueryCheckProof() {
 	suite.True(success, stderr)
 	suite.Contains(stdout, `"success":true`)
 
+	// Proofs must be confirmed on the best chain
+	ancestor := hex.EncodeToString(genesisHeaders[1].Hash[:])
+	currentBest := hex.EncodeToString(genesisHeaders[1].Raw[:])
+	newBest := hex.EncodeToString(suite.TestData.NewHeaders[len(suite.TestData.NewHeaders)-1].Raw[:])
+	success, stdout, stderr = f.TxMarkNewHeaviest(fooAddr, ancestor, currentBest, newBest, "20", "--gas 500000 -y")
+	suite.True(success, stderr)
+	suite.Contains(stdout, `"success":true`)
+
 	// Require proof is valid when associated header exists with valid transaction
 	checkProof = f.QueryCheckProof("1_check_proof.json", "--inputfile")
 	expected = true
@@ -317,6 +329,14 @@ func (suite *UtilsSuite) TestRelayCLIQueryCheckRequests() {
 	suite.True(success, stderr)
 	suite.Contains(stdout, `"success":true`)
 
+	// Proofs must be confirmed on the best chain
+	ancestor := hex.EncodeToString(genesisHeaders[1].Hash[:])
+	currentBest := hex.EncodeToString(genesisHeaders[1].Raw[:])
+	newBest := hex.EncodeToString(suite.TestData.NewHeaders[len(suite.TestData.NewHeaders)-1].Raw[:])
+	success, stdout, stderr = f.TxMarkNewHeaviest(fooAddr, ancestor, currentBest, newBest, "20", "--gas 500000 -y")
+	suite.True(success, stderr)
+	suite.Contains(stdout, `"success":true`)
+
 	// require checkrequests fails given invalid proof requests
 	checkrequests := f.QueryCheckRequests("1_check_proof.json", "3_filled_requests.json", "--inputfile")
 	actual := checkrequests.Valid
@@ -342,6 +362,248 @@ func (suite *UtilsSuite) TestRelayCLIQueryCheckRequests() {
 	f.Cleanup()
 }
 
+func (suite *UtilsSuite) TestRelayCLIQueryGetHeader() {
+	suite.T().Parallel()
+
+	genesisHeaders := suite.TestData.GenesisHeaders
+
+	// Initialize chain
+	f := InitFixtures(suite.T())
+	proc := f.RelayDStart()
+	defer func() {
+		err := proc.Stop(false)
+		suite.NoError(err)
+	}()
+
+	// Define parameter values
+	digest := hex.EncodeToString(genesisHeaders[1].Hash[:])
+	height := strconv.FormatUint(uint64(genesisHeaders[1].Height), 10)
+
+	// getheader returns the stored relay genesis
+	getHeader := f.QueryGetHeader(digest)
+	suite.Equal(genesisHeaders[1], getHeader.Res)
+
+	// getheaderbyheight returns the relay genesis at its height
+	getHeaderByHeight := f.QueryGetHeaderByHeight(height)
+	suite.Equal(genesisHeaders[1], getHeaderByHeight.Res)
+
+	//Cleanup
+	f.Cleanup()
+}
+
+func (suite *UtilsSuite) TestRelayCLIQueryGetChainWork() {
+	suite.T().Parallel()
+
+	genesisHeaders := suite.TestData.GenesisHeaders
+	newDiffHeaders := suite.TestData.NewDiffHeaders
+
+	// Initialize chain
+	f := InitFixtures(suite.T())
+	proc := f.RelayDStart()
+	defer func() {
+		err := proc.Stop(false)
+		suite.NoError(err)
+	}()
+
+	// Define parameter values
+	fooAddr := f.KeyAddress(keyFoo)
+	prevEpochStart := hex.EncodeToString(genesisHeaders[0].Hash[:])
+	genesisDigest := hex.EncodeToString(genesisHeaders[1].Hash[:])
+	tipDigest := hex.EncodeToString(newDiffHeaders[len(newDiffHeaders)-1].Hash[:])
+
+	genesisWork := f.QueryGetChainWork(genesisDigest).Res
+	suite.False(genesisWork.IsZero())
+
+	// Ingest Headers w/ Diff Change
+	success, stdout, stderr := f.TxIngestDiffChange(fooAddr, prevEpochStart, "0_new_difficulty.json", "--inputfile -y")
+	suite.True(success, stderr)
+	suite.Contains(stdout, `"success":true`)
+
+	// the new tip has accumulated more work than genesis
+	tipWork := f.QueryGetChainWork(tipDigest).Res
+	suite.True(tipWork.GT(genesisWork))
+
+	//Cleanup
+	f.Cleanup()
+}
+
+func (suite *UtilsSuite) TestRelayCLIQueryGetChainParams() {
+	suite.T().Parallel()
+
+	// Initialize chain
+	f := InitFixtures(suite.T())
+	proc := f.RelayDStart()
+	defer func() {
+		err := proc.Stop(false)
+		suite.NoError(err)
+	}()
+
+	// the default genesis follows mainnet
+	chainParams := f.QueryGetChainParams()
+	suite.Equal(rtypes.MainnetParams(), chainParams.Res)
+
+	//Cleanup
+	f.Cleanup()
+}
+
+func (suite *UtilsSuite) TestRelayCLIQueryGetTips() {
+	suite.T().Parallel()
+
+	genesisHeaders := suite.TestData.GenesisHeaders
+
+	// Initialize chain
+	f := InitFixtures(suite.T())
+	proc := f.RelayDStart()
+	defer func() {
+		err := proc.Stop(false)
+		suite.NoError(err)
+	}()
+
+	// the genesis headers form a single branch from the best digest
+	fooAddr := f.KeyAddress(keyFoo)
+	bestDigest := f.QueryGetBestDigest(fooAddr).Res
+	tips := f.QueryGetTips().Res
+	suite.Equal(1, len(tips))
+	suite.Equal(genesisHeaders[len(genesisHeaders)-1].Hash, tips[0].Digest)
+	suite.Equal(bestDigest, tips[0].ForkPoint)
+
+	//Cleanup
+	f.Cleanup()
+}
+
+func (suite *UtilsSuite) TestRelayCLIQueryGetMMR() {
+	suite.T().Parallel()
+
+	// Initialize chain
+	f := InitFixtures(suite.T())
+	proc := f.RelayDStart()
+	defer func() {
+		err := proc.Stop(false)
+		suite.NoError(err)
+	}()
+
+	// the best digest is the last leaf, and its proof verifies
+	fooAddr := f.KeyAddress(keyFoo)
+	bestDigest := f.QueryGetBestDigest(fooAddr).Res
+	root := f.QueryGetMMRRoot().Res
+	suite.NotZero(root.LeafCount)
+	proof := f.QueryGetMMRProof(root.BaseHeight + root.LeafCount - 1).Res
+	suite.Equal(bestDigest, proof.Leaf)
+	suite.True(proof.Verify(root))
+
+	//Cleanup
+	f.Cleanup()
+}
+
+func (suite *UtilsSuite) TestRelayCLIQueryGetHeaders() {
+	suite.T().Parallel()
+
+	// Initialize chain
+	f := InitFixtures(suite.T())
+	proc := f.RelayDStart()
+	defer func() {
+		err := proc.Stop(false)
+		suite.NoError(err)
+	}()
+
+	// the range stops at the best digest
+	fooAddr := f.KeyAddress(keyFoo)
+	bestDigest := f.QueryGetBestDigest(fooAddr).Res
+	headers := f.QueryGetHeaders(hex.EncodeToString(bestDigest[:]), 10).Res
+	suite.Equal(1, len(headers))
+	suite.Equal(bestDigest, headers[0].Hash)
+
+	// and can start from a height
+	height := strconv.FormatUint(uint64(headers[0].Height), 10)
+	suite.Equal(headers, f.QueryGetHeaders(height, 10).Res)
+
+	//Cleanup
+	f.Cleanup()
+}
+
+func (suite *UtilsSuite) TestRelayCLIQueryLocateHeaders() {
+	suite.T().Parallel()
+
+	// Initialize chain
+	f := InitFixtures(suite.T())
+	proc := f.RelayDStart()
+	defer func() {
+		err := proc.Stop(false)
+		suite.NoError(err)
+	}()
+
+	// unknown digests are skipped
+	fooAddr := f.KeyAddress(keyFoo)
+	bestDigest := f.QueryGetBestDigest(fooAddr).Res
+	locator := fmt.Sprintf(`["0x%s","0x%s"]`, strings.Repeat("11", 32), hex.EncodeToString(bestDigest[:]))
+	located := f.QueryLocateHeaders(locator, 10).Res
+	suite.Equal(bestDigest, located.Fork.Hash)
+	suite.Equal(0, len(located.Headers))
+
+	//Cleanup
+	f.Cleanup()
+}
+
+func (suite *UtilsSuite) TestRelayCLIQueryEpochDifficulties() {
+	suite.T().Parallel()
+
+	// Initialize chain
+	f := InitFixtures(suite.T())
+	proc := f.RelayDStart()
+	defer func() {
+		err := proc.Stop(false)
+		suite.NoError(err)
+	}()
+
+	// the relay has not seen a retarget
+	suite.False(f.QueryGetCurrentEpochDifficulty().Res.IsZero())
+	suite.True(f.QueryGetPrevEpochDifficulty().Res.IsZero())
+
+	//Cleanup
+	f.Cleanup()
+}
+
+func (suite *UtilsSuite) TestRelayCLIQueryFindHeight() {
+	suite.T().Parallel()
+
+	// Initialize chain
+	f := InitFixtures(suite.T())
+	proc := f.RelayDStart()
+	defer func() {
+		err := proc.Stop(false)
+		suite.NoError(err)
+	}()
+
+	// agrees with the header
+	fooAddr := f.KeyAddress(keyFoo)
+	bestDigest := f.QueryGetBestDigest(fooAddr).Res
+	digest := hex.EncodeToString(bestDigest[:])
+	header := f.QueryGetHeaders(digest, 1).Res[0]
+	suite.Equal(header.Height, f.QueryFindHeight(digest).Res)
+
+	//Cleanup
+	f.Cleanup()
+}
+
+func (suite *UtilsSuite) TestRelayCLIQueryParams() {
+	suite.T().Parallel()
+
+	// Initialize chain
+	f := InitFixtures(suite.T())
+	proc := f.RelayDStart()
+	defer func() {
+		err := proc.Stop(false)
+		suite.NoError(err)
+	}()
+
+	// the default genesis uses the default params
+	params := f.QueryParams()
+	suite.Equal(rtypes.DefaultParams(), params.Res)
+
+	//Cleanup
+	f.Cleanup()
+}
+
 func (suite *UtilsSuite) TestRelayCLITXIngestHeaders() {
 	suite.T().Parallel()
 
@@ -359,10 +621,11 @@ func (suite *UtilsSuite) TestRelayCLITXIngestHeaders() {
 	fooAddr := f.KeyAddress(keyFoo)
 	prevEpochStart := hex.EncodeToString(genesisHeaders[0].Hash[:])
 
-	// Require IngestDiffChange fails with invalid headers
+	// Headers sent before their anchor are held in the orphan pool
 	success, stdout, stderr := f.TxIngestHeaders(fooAddr, "2_ingest_headers.json", "--inputfile -y")
 	suite.True(success, stderr)
-	suite.Contains(stdout, `"success":false`)
+	suite.Contains(stdout, `"success":true`)
+	suite.Contains(stdout, `"orphan_chain"`)
 
 	//Ingest Difficulty Change Headers
 	success, stdout, stderr = f.TxIngestDiffChange(fooAddr, prevEpochStart, "0_new_difficulty.json", "--inputfile -y")
@@ -435,6 +698,14 @@ func (suite *UtilsSuite) TestRelayCLITXProvideProof() {
 	suite.True(success, stderr)
 	suite.Contains(stdout, `"success":true`)
 
+	// Proofs must be confirmed on the best chain
+	ancestor := hex.EncodeToString(genesisHeaders[1].Hash[:])
+	currentBest := hex.EncodeToString(genesisHeaders[1].Raw[:])
+	newBest := hex.EncodeToString(suite.TestData.NewHeaders[len(suite.TestData.NewHeaders)-1].Raw[:])
+	success, stdout, stderr = f.TxMarkNewHeaviest(fooAddr, ancestor, currentBest, newBest, "20", "--gas 500000 -y")
+	suite.True(success, stderr)
+	suite.Contains(stdout, `"success":true`)
+
 	// require checkproof fails given invalid proof requests
 	_, stdout, _ = f.TxProvideProof(fooAddr, "1_check_proof.json", "3_filled_requests.json", "--inputfile -y")
 	suite.Contains(stdout, `"Request not found`)
@@ -453,6 +724,75 @@ func (suite *UtilsSuite) TestRelayCLITXProvideProof() {
 	suite.True(success, stderr)
 	suite.Contains(stdout, `"success":true`)
 
+	// the proof can not be replayed
+	success, stdout, stderr = f.TxProvideProof(fooAddr, "1_check_proof.json", "3_filled_requests.json", "--inputfile -y")
+	suite.True(success, stderr)
+	suite.Contains(stdout, `"code":614`)
+
+	// the transaction is recorded with the request it filled
+	proven := f.QueryGetProvenTx("f2147d83f9b048ebbffa04ebaf76341c56908e44aeb9bce27e727328bb00fc7d")
+	suite.Equal(1, len(proven.Res.Requests))
+	suite.False(proven.Res.Requests[0].Request.ActiveState)
+
+	//Cleanup
+	f.Cleanup()
+}
+
+func (suite *UtilsSuite) TestRelayCLITxCancelRequest() {
+	suite.T().Parallel()
+
+	// Initialize chain
+	f := InitFixtures(suite.T())
+	proc := f.RelayDStart()
+	defer func() {
+		err := proc.Stop(false)
+		suite.NoError(err)
+	}()
+
+	fooAddr := f.KeyAddress(keyFoo)
+
+	// cancelling an unknown request fails
+	success, stdout, stderr := f.TxCancelRequest(fooAddr, "0", "-y")
+	suite.True(success, stderr)
+	suite.Contains(stdout, `"success":false`)
+
+	// submit proof request
+	success, stdout, stderr = f.TxNewRequest(fooAddr, "0x", "0x17a91423737cd98bb6b2da5a11bcd82e5de36591d69f9f87", "0", "1", "-y")
+	suite.True(success, stderr)
+	suite.Contains(stdout, `"success":true`)
+
+	request := f.QueryGetRequest("0")
+	suite.Equal(fooAddr, request.Res.Creator)
+	suite.True(request.Res.ActiveState)
+	suite.Equal(uint32(1), request.Res.MaxFills)
+
+	// the creator cancels the request
+	success, stdout, stderr = f.TxCancelRequest(fooAddr, "0", "-y")
+	suite.True(success, stderr)
+	suite.Contains(stdout, `"success":true`)
+	suite.Contains(stdout, "request_cancelled")
+
+	request = f.QueryGetRequest("0")
+	suite.False(request.Res.ActiveState)
+
+	// submit proof request with a bounty for each of 2 fills
+	bounty := fmt.Sprintf("10%s", fooDenom)
+	success, stdout, stderr = f.TxNewRequest(fooAddr, "0x", "0x17a91423737cd98bb6b2da5a11bcd82e5de36591d69f9f87", "0", "1", "2", "--bounty", bounty, "-y")
+	suite.True(success, stderr)
+	suite.Contains(stdout, `"success":true`)
+
+	request = f.QueryGetRequest("1")
+	suite.Equal(bounty, request.Res.Bounty.String())
+	suite.Equal(fmt.Sprintf("20%s", fooDenom), request.Res.Escrow.String())
+
+	// cancelling refunds the escrow
+	success, stdout, stderr = f.TxCancelRequest(fooAddr, "1", "-y")
+	suite.True(success, stderr)
+	suite.Contains(stdout, `"success":true`)
+
+	request = f.QueryGetRequest("1")
+	suite.Equal(0, len(request.Res.Escrow))
+
 	//Cleanup
 	f.Cleanup()
 }
@@ -362,6 +362,186 @@ func (f *Fixtures) QueryCheckRequests(proof, requests string, flags ...string) r
 	return checkrequests
 }
 
+// QueryGetHeader returns the header stored under a digest
+func (f *Fixtures) QueryGetHeader(digest string) rtypes.QueryResGetHeader {
+	cmd := fmt.Sprintf("%s query relay getheader %s %s", f.RelaycliBinary, digest, f.Flags())
+	res, errStr := tests.ExecuteT(f.T, cmd, "")
+	require.Empty(f.T, errStr)
+	cdc := app.MakeCodec()
+	var getheader rtypes.QueryResGetHeader
+	err := cdc.UnmarshalJSON([]byte(res), &getheader)
+	require.NoError(f.T, err)
+	return getheader
+}
+
+// QueryGetHeaderByHeight returns the best chain header at a height
+func (f *Fixtures) QueryGetHeaderByHeight(height string) rtypes.QueryResGetHeaderByHeight {
+	cmd := fmt.Sprintf("%s query relay getheaderbyheight %s %s", f.RelaycliBinary, height, f.Flags())
+	res, errStr := tests.ExecuteT(f.T, cmd, "")
+	require.Empty(f.T, errStr)
+	cdc := app.MakeCodec()
+	var getheaderbyheight rtypes.QueryResGetHeaderByHeight
+	err := cdc.UnmarshalJSON([]byte(res), &getheaderbyheight)
+	require.NoError(f.T, err)
+	return getheaderbyheight
+}
+
+// QueryGetChainWork returns the accumulated work of the chain ending in a digest
+func (f *Fixtures) QueryGetChainWork(digest string) rtypes.QueryResGetChainWork {
+	cmd := fmt.Sprintf("%s query relay getchainwork %s %s", f.RelaycliBinary, digest, f.Flags())
+	res, errStr := tests.ExecuteT(f.T, cmd, "")
+	require.Empty(f.T, errStr)
+	cdc := app.MakeCodec()
+	var getchainwork rtypes.QueryResGetChainWork
+	err := cdc.UnmarshalJSON([]byte(res), &getchainwork)
+	require.NoError(f.T, err)
+	return getchainwork
+}
+
+// QueryGetChainParams returns the parameters of the Bitcoin network the relay follows
+func (f *Fixtures) QueryGetChainParams() rtypes.QueryResGetChainParams {
+	cmd := fmt.Sprintf("%s query relay getchainparams %s", f.RelaycliBinary, f.Flags())
+	res, errStr := tests.ExecuteT(f.T, cmd, "")
+	require.Empty(f.T, errStr)
+	cdc := app.MakeCodec()
+	var getchainparams rtypes.QueryResGetChainParams
+	err := cdc.UnmarshalJSON([]byte(res), &getchainparams)
+	require.NoError(f.T, err)
+	return getchainparams
+}
+
+// QueryGetTips returns every known chain tip
+func (f *Fixtures) QueryGetTips() rtypes.QueryResGetTips {
+	cmd := fmt.Sprintf("%s query relay gettips %s", f.RelaycliBinary, f.Flags())
+	res, errStr := tests.ExecuteT(f.T, cmd, "")
+	require.Empty(f.T, errStr)
+	cdc := app.MakeCodec()
+	var gettips rtypes.QueryResGetTips
+	err := cdc.UnmarshalJSON([]byte(res), &gettips)
+	require.NoError(f.T, err)
+	return gettips
+}
+
+// QueryGetMMRRoot returns the root of the MMR over the best chain
+func (f *Fixtures) QueryGetMMRRoot() rtypes.QueryResGetMMRRoot {
+	cmd := fmt.Sprintf("%s query relay getmmrroot %s", f.RelaycliBinary, f.Flags())
+	res, errStr := tests.ExecuteT(f.T, cmd, "")
+	require.Empty(f.T, errStr)
+	cdc := app.MakeCodec()
+	var getmmrroot rtypes.QueryResGetMMRRoot
+	err := cdc.UnmarshalJSON([]byte(res), &getmmrroot)
+	require.NoError(f.T, err)
+	return getmmrroot
+}
+
+// QueryGetMMRProof returns an MMR proof for the best chain block at a height
+func (f *Fixtures) QueryGetMMRProof(height uint32) rtypes.QueryResGetMMRProof {
+	cmd := fmt.Sprintf("%s query relay getmmrproof %d %s", f.RelaycliBinary, height, f.Flags())
+	res, errStr := tests.ExecuteT(f.T, cmd, "")
+	require.Empty(f.T, errStr)
+	cdc := app.MakeCodec()
+	var getmmrproof rtypes.QueryResGetMMRProof
+	err := cdc.UnmarshalJSON([]byte(res), &getmmrproof)
+	require.NoError(f.T, err)
+	return getmmrproof
+}
+
+// QueryGetHeaders returns consecutive best chain headers from a digest or height
+func (f *Fixtures) QueryGetHeaders(start string, count uint32) rtypes.QueryResGetHeaders {
+	cmd := fmt.Sprintf("%s query relay getheaders %s %d %s", f.RelaycliBinary, start, count, f.Flags())
+	res, errStr := tests.ExecuteT(f.T, cmd, "")
+	require.Empty(f.T, errStr)
+	cdc := app.MakeCodec()
+	var getheaders rtypes.QueryResGetHeaders
+	err := cdc.UnmarshalJSON([]byte(res), &getheaders)
+	require.NoError(f.T, err)
+	return getheaders
+}
+
+// QueryLocateHeaders returns the highest locator digest on the best chain, and the headers after it
+func (f *Fixtures) QueryLocateHeaders(jsonLocator string, count uint32) rtypes.QueryResLocateHeaders {
+	cmd := fmt.Sprintf("%s query relay locateheaders %s %d %s", f.RelaycliBinary, jsonLocator, count, f.Flags())
+	res, errStr := tests.ExecuteT(f.T, cmd, "")
+	require.Empty(f.T, errStr)
+	cdc := app.MakeCodec()
+	var locateheaders rtypes.QueryResLocateHeaders
+	err := cdc.UnmarshalJSON([]byte(res), &locateheaders)
+	require.NoError(f.T, err)
+	return locateheaders
+}
+
+// QueryGetCurrentEpochDifficulty returns the difficulty of the current epoch
+func (f *Fixtures) QueryGetCurrentEpochDifficulty() rtypes.QueryResGetCurrentEpochDifficulty {
+	cmd := fmt.Sprintf("%s query relay getcurrentepochdifficulty %s", f.RelaycliBinary, f.Flags())
+	res, errStr := tests.ExecuteT(f.T, cmd, "")
+	require.Empty(f.T, errStr)
+	cdc := app.MakeCodec()
+	var getcurrentepochdifficulty rtypes.QueryResGetCurrentEpochDifficulty
+	err := cdc.UnmarshalJSON([]byte(res), &getcurrentepochdifficulty)
+	require.NoError(f.T, err)
+	return getcurrentepochdifficulty
+}
+
+// QueryGetPrevEpochDifficulty returns the difficulty of the previous epoch
+func (f *Fixtures) QueryGetPrevEpochDifficulty() rtypes.QueryResGetPrevEpochDifficulty {
+	cmd := fmt.Sprintf("%s query relay getprevepochdifficulty %s", f.RelaycliBinary, f.Flags())
+	res, errStr := tests.ExecuteT(f.T, cmd, "")
+	require.Empty(f.T, errStr)
+	cdc := app.MakeCodec()
+	var getprevepochdifficulty rtypes.QueryResGetPrevEpochDifficulty
+	err := cdc.UnmarshalJSON([]byte(res), &getprevepochdifficulty)
+	require.NoError(f.T, err)
+	return getprevepochdifficulty
+}
+
+// QueryFindHeight returns the height of a stored header
+func (f *Fixtures) QueryFindHeight(digest string) rtypes.QueryResFindHeight {
+	cmd := fmt.Sprintf("%s query relay findheight %s %s", f.RelaycliBinary, digest, f.Flags())
+	res, errStr := tests.ExecuteT(f.T, cmd, "")
+	require.Empty(f.T, errStr)
+	cdc := app.MakeCodec()
+	var findheight rtypes.QueryResFindHeight
+	err := cdc.UnmarshalJSON([]byte(res), &findheight)
+	require.NoError(f.T, err)
+	return findheight
+}
+
+// QueryGetRequest returns a proof request
+func (f *Fixtures) QueryGetRequest(id string) rtypes.QueryResGetRequest {
+	cmd := fmt.Sprintf("%s query relay getrequest %s %s", f.RelaycliBinary, id, f.Flags())
+	res, errStr := tests.ExecuteT(f.T, cmd, "")
+	require.Empty(f.T, errStr)
+	cdc := app.MakeCodec()
+	var request rtypes.QueryResGetRequest
+	err := cdc.UnmarshalJSON([]byte(res), &request)
+	require.NoError(f.T, err)
+	return request
+}
+
+// QueryGetProvenTx returns the requests a transaction filled
+func (f *Fixtures) QueryGetProvenTx(txid string) rtypes.QueryResGetProvenTx {
+	cmd := fmt.Sprintf("%s query relay getproventx %s %s", f.RelaycliBinary, txid, f.Flags())
+	res, errStr := tests.ExecuteT(f.T, cmd, "")
+	require.Empty(f.T, errStr)
+	cdc := app.MakeCodec()
+	var proven rtypes.QueryResGetProvenTx
+	err := cdc.UnmarshalJSON([]byte(res), &proven)
+	require.NoError(f.T, err)
+	return proven
+}
+
+// QueryParams returns the relay's governance-tunable limits
+func (f *Fixtures) QueryParams() rtypes.QueryResParams {
+	cmd := fmt.Sprintf("%s query relay params %s", f.RelaycliBinary, f.Flags())
+	res, errStr := tests.ExecuteT(f.T, cmd, "")
+	require.Empty(f.T, errStr)
+	cdc := app.MakeCodec()
+	var params rtypes.QueryResParams
+	err := cdc.UnmarshalJSON([]byte(res), &params)
+	require.NoError(f.T, err)
+	return params
+}
+
 /////////////////////////////////////////////////////////////////////
 // CLI Transactions /////////////////////////////////////////////////
 /////////////////////////////////////////////////////////////////////
@@ -390,6 +570,12 @@ func (f *Fixtures) TxProvideProof(delAddr sdk.AccAddress, proof, listofrequests
 	return executeWriteRetStdStreams(f.T, addFlags(cmd, flags), clientkeys.DefaultKeyPass)
 }
 
+// TxCancelRequest is a relaycli tx that cancels a Proof Request
+func (f *Fixtures) TxCancelRequest(delAddr sdk.AccAddress, id string, flags ...string) (bool, string, string) {
+	cmd := fmt.Sprintf("%s tx relay cancelrequest %s --from %s %s", f.RelaycliBinary, id, delAddr, f.Flags())
+	return executeWriteRetStdStreams(f.T, addFlags(cmd, flags), clientkeys.DefaultKeyPass)
+}
+
 // TxMarkNewHeaviest returns Last Common Anscestor
 func (f *Fixtures) TxMarkNewHeaviest(delAddr sdk.AccAddress, ancestor, currentBest, newBest, limit string, flags ...string) (bool, string, string) {
 	cmd := fmt.Sprintf("%s tx relay marknewheaviest %s %s %s %s --from %s %s", f.RelaycliBinary, ancestor, currentBest, newBest, limit, delAddr, f.Flags())
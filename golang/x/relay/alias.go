@@ -12,6 +12,10 @@ const (
 	RouterKey = types.RouterKey
 	//StoreKey is what it says on the tin
 	StoreKey = types.StoreKey
+	// Mainnet is the Bitcoin main network
+	Mainnet = types.Mainnet
+	// DefaultParamspace is the name of the relay's params subspace
+	DefaultParamspace = types.DefaultParamspace
 )
 
 var (
@@ -29,10 +33,22 @@ var (
 	NewMsgNewRequest = types.NewMsgNewRequest
 	// NewMsgProvideProof is what is says on the tin
 	NewMsgProvideProof = types.NewMsgProvideProof
+	// NewMsgCancelRequest is what is says on the tin
+	NewMsgCancelRequest = types.NewMsgCancelRequest
 	// RegisterCodec is what is says on the tin
 	RegisterCodec = types.RegisterCodec
 	// ModuleCdc is what is says on the tin
 	ModuleCdc = types.ModuleCdc
+	// ChainParamsForNetwork is what is says on the tin
+	ChainParamsForNetwork = types.ChainParamsForNetwork
+	// NewCheckpointProposal is what is says on the tin
+	NewCheckpointProposal = types.NewCheckpointProposal
+	// NewProposalHandler is what is says on the tin
+	NewProposalHandler = keeper.NewProposalHandler
+	// DefaultParams is what is says on the tin
+	DefaultParams = types.DefaultParams
+	// NewMultiRelayHooks is what is says on the tin
+	NewMultiRelayHooks = types.NewMultiRelayHooks
 )
 
 type (
@@ -64,4 +80,28 @@ type (
 
 	// NullHandler does nothing
 	NullHandler = types.NullHandler
+
+	// RelayHooks is an interface the keeper notifies of chain changes
+	RelayHooks = types.RelayHooks
+
+	// MultiRelayHooks combines multiple relay hooks
+	MultiRelayHooks = types.MultiRelayHooks
+
+	// ChainState is a complete snapshot of the relay's store
+	ChainState = types.ChainState
+
+	// Network identifies a Bitcoin network
+	Network = types.Network
+
+	// ChainParams holds the consensus parameters of a Bitcoin network
+	ChainParams = types.ChainParams
+
+	// Checkpoint pins the best chain block at a height to a digest
+	Checkpoint = types.Checkpoint
+
+	// CheckpointProposal is a gov proposal to change the relay's checkpoints
+	CheckpointProposal = types.CheckpointProposal
+
+	// Params are the governance-tunable limits of the relay
+	Params = types.Params
 )
@@ -32,6 +32,21 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 		GetCmdHeaviestFromAncestor(queryRoute, cdc),
 		GetCmdCheckProof(queryRoute, cdc),
 		GetCmdCheckRequests(queryRoute, cdc),
+		GetCmdGetRequest(queryRoute, cdc),
+		GetCmdGetProvenTx(queryRoute, cdc),
+		GetCmdGetHeader(queryRoute, cdc),
+		GetCmdGetHeaderByHeight(queryRoute, cdc),
+		GetCmdGetChainWork(queryRoute, cdc),
+		GetCmdGetCurrentEpochDifficulty(queryRoute, cdc),
+		GetCmdGetPrevEpochDifficulty(queryRoute, cdc),
+		GetCmdFindHeight(queryRoute, cdc),
+		GetCmdGetChainParams(queryRoute, cdc),
+		GetCmdGetTips(queryRoute, cdc),
+		GetCmdGetMMRRoot(queryRoute, cdc),
+		GetCmdGetMMRProof(queryRoute, cdc),
+		GetCmdGetHeaders(queryRoute, cdc),
+		GetCmdLocateHeaders(queryRoute, cdc),
+		GetCmdParams(queryRoute, cdc),
 	)...)
 	return relayQueryCommand
 }
@@ -387,6 +402,46 @@ func GetCmdGetRequest(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	}
 }
 
+// GetCmdGetProvenTx returns the CLI command struct for getProvenTx
+func GetCmdGetProvenTx(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "getproventx <txid>",
+		Example: "getproventx d60033c5cf5c199208a9c656a29967810c4e428c22efb492fdd816e6a0a1e548",
+		Long:    "Get the requests a transaction has filled, and the header that confirmed it, by its LE TXID. Errors if the transaction has not filled any requests",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			txid, sdkErr := types.Hash256DigestFromHex(args[0])
+			if sdkErr != nil {
+				fmt.Print(sdkErr.Error())
+				return nil
+			}
+
+			params := types.QueryParamsGetProvenTx{
+				TxID: txid,
+			}
+
+			queryData, err := cdc.MarshalJSON(params)
+			if err != nil {
+				fmt.Print(err.Error())
+				return nil
+			}
+
+			res, _, err := cliCtx.QueryWithData("custom/relay/getproventx", queryData)
+
+			if err != nil {
+				fmt.Printf("could not find proven tx %s... \n", args[0][:8])
+				return nil
+			}
+
+			var out types.QueryResGetProvenTx
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(&out)
+		},
+	}
+}
+
 // GetCmdCheckRequests returns the CLI command struct for checkRequests
 func GetCmdCheckRequests(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -514,3 +569,447 @@ Use flag --inputfile to submit a json filename as input from scripts/seed_data d
 	attachFlagFileinput(cmd)
 	return cmd
 }
+
+// GetCmdGetHeader returns the CLI command struct for getHeader
+func GetCmdGetHeader(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "getheader <digest>",
+		Example: "getheader f8d0a038bfe4027e5de3b6bf07262122636fd2916d7503000000000000000000",
+		Long:    "Get a stored header by its LE digest. Errors if the digest is unknown",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			digest, sdkErr := types.Hash256DigestFromHex(args[0])
+			if sdkErr != nil {
+				fmt.Print(sdkErr.Error())
+				return nil
+			}
+
+			params := types.QueryParamsGetHeader{
+				DigestLE: digest,
+			}
+
+			queryData, err := cdc.MarshalJSON(params)
+			if err != nil {
+				fmt.Print(err.Error())
+				return nil
+			}
+
+			res, _, err := cliCtx.QueryWithData("custom/relay/getheader", queryData)
+
+			if err != nil {
+				fmt.Printf("could not find header %s... \n", args[0][:8])
+				return nil
+			}
+
+			var out types.QueryResGetHeader
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(&out)
+		},
+	}
+}
+
+// GetCmdGetHeaderByHeight returns the CLI command struct for getHeaderByHeight
+func GetCmdGetHeaderByHeight(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "getheaderbyheight <height>",
+		Example: "getheaderbyheight 606210",
+		Long:    "Get the best chain header at a height. Errors if the relay has no best chain block at that height",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			height, err := strconv.ParseUint(args[0], 10, 32)
+			if err != nil {
+				fmt.Print(err.Error())
+				return nil
+			}
+
+			params := types.QueryParamsGetHeaderByHeight{
+				Height: uint32(height),
+			}
+
+			queryData, err := cdc.MarshalJSON(params)
+			if err != nil {
+				fmt.Print(err.Error())
+				return nil
+			}
+
+			res, _, err := cliCtx.QueryWithData("custom/relay/getheaderbyheight", queryData)
+
+			if err != nil {
+				fmt.Printf("could not find header at height %s \n", args[0])
+				return nil
+			}
+
+			var out types.QueryResGetHeaderByHeight
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(&out)
+		},
+	}
+}
+
+// GetCmdGetChainWork returns the CLI command struct for getChainWork
+func GetCmdGetChainWork(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "getchainwork <digest>",
+		Example: "getchainwork f8d0a038bfe4027e5de3b6bf07262122636fd2916d7503000000000000000000",
+		Long:    "Get the accumulated work of the chain ending in <digest>, counted from the relay genesis",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			digest, sdkErr := types.Hash256DigestFromHex(args[0])
+			if sdkErr != nil {
+				fmt.Print(sdkErr.Error())
+				return nil
+			}
+
+			params := types.QueryParamsGetChainWork{
+				DigestLE: digest,
+			}
+
+			queryData, err := cdc.MarshalJSON(params)
+			if err != nil {
+				fmt.Print(err.Error())
+				return nil
+			}
+
+			res, _, err := cliCtx.QueryWithData("custom/relay/getchainwork", queryData)
+
+			if err != nil {
+				fmt.Printf("could not get chain work of %s... \n", args[0][:8])
+				return nil
+			}
+
+			var out types.QueryResGetChainWork
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(&out)
+		},
+	}
+}
+
+// GetCmdGetCurrentEpochDifficulty returns the CLI command struct for getCurrentEpochDifficulty
+func GetCmdGetCurrentEpochDifficulty(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "getcurrentepochdifficulty",
+		Example: "getcurrentepochdifficulty",
+		Long:    "Get the difficulty of the best chain's current epoch",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData("custom/relay/getcurrentepochdifficulty", nil)
+
+			if err != nil {
+				fmt.Println("could not get the current epoch difficulty")
+				return nil
+			}
+
+			var out types.QueryResGetCurrentEpochDifficulty
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(&out)
+		},
+	}
+}
+
+// GetCmdGetPrevEpochDifficulty returns the CLI command struct for getPrevEpochDifficulty
+func GetCmdGetPrevEpochDifficulty(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "getprevepochdifficulty",
+		Example: "getprevepochdifficulty",
+		Long:    "Get the difficulty of the epoch before the best chain's current epoch. It is 0 until the relay has seen a retarget",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData("custom/relay/getprevepochdifficulty", nil)
+
+			if err != nil {
+				fmt.Println("could not get the previous epoch difficulty")
+				return nil
+			}
+
+			var out types.QueryResGetPrevEpochDifficulty
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(&out)
+		},
+	}
+}
+
+// GetCmdFindHeight returns the CLI command struct for findHeight
+func GetCmdFindHeight(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "findheight <digest>",
+		Example: "findheight f8d0a038bfe4027e5de3b6bf07262122636fd2916d7503000000000000000000",
+		Long:    "Get the height of a stored header, whether or not it is on the best chain",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			digest, sdkErr := types.Hash256DigestFromHex(args[0])
+			if sdkErr != nil {
+				fmt.Print(sdkErr.Error())
+				return nil
+			}
+
+			params := types.QueryParamsFindHeight{
+				DigestLE: digest,
+			}
+
+			queryData, err := cdc.MarshalJSON(params)
+			if err != nil {
+				fmt.Print(err.Error())
+				return nil
+			}
+
+			res, _, err := cliCtx.QueryWithData("custom/relay/findheight", queryData)
+
+			if err != nil {
+				fmt.Printf("could not find height of %s... \n", args[0][:8])
+				return nil
+			}
+
+			var out types.QueryResFindHeight
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(&out)
+		},
+	}
+}
+
+// GetCmdGetChainParams returns the CLI command struct for getChainParams
+func GetCmdGetChainParams(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "getchainparams",
+		Example: "getchainparams",
+		Long:    "Get the parameters of the Bitcoin network the relay follows",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData("custom/relay/getchainparams", nil)
+
+			if err != nil {
+				fmt.Println("could not get the chain params")
+				return nil
+			}
+
+			var out types.QueryResGetChainParams
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(&out)
+		},
+	}
+}
+
+// GetCmdParams returns the CLI command struct for params
+func GetCmdParams(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "params",
+		Example: "params",
+		Long:    "Get the relay's governance-tunable limits",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData("custom/relay/params", nil)
+
+			if err != nil {
+				fmt.Println("could not get the relay params")
+				return nil
+			}
+
+			var out types.QueryResParams
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(&out)
+		},
+	}
+}
+
+// GetCmdGetTips returns the CLI command struct for getTips
+func GetCmdGetTips(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "gettips",
+		Example: "gettips",
+		Long:    "Get every known chain tip, with its fork point relative to the best chain",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData("custom/relay/gettips", nil)
+
+			if err != nil {
+				fmt.Println("could not get the chain tips")
+				return nil
+			}
+
+			var out types.QueryResGetTips
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(&out)
+		},
+	}
+}
+
+// GetCmdGetMMRRoot returns the CLI command struct for getMMRRoot
+func GetCmdGetMMRRoot(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "getmmrroot",
+		Example: "getmmrroot",
+		Long:    "Get the root of the Merkle Mountain Range over the best chain, and its leaf count",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData("custom/relay/getmmrroot", nil)
+
+			if err != nil {
+				fmt.Println("could not get the MMR root")
+				return nil
+			}
+
+			var out types.QueryResGetMMRRoot
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(&out)
+		},
+	}
+}
+
+// GetCmdGetMMRProof returns the CLI command struct for getMMRProof
+func GetCmdGetMMRProof(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "getmmrproof <height>",
+		Example: "getmmrproof 606210",
+		Long:    "Get a proof that the best chain block at a height is in the Merkle Mountain Range. Blocks that have been pruned can still be proven",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			height, err := strconv.ParseUint(args[0], 10, 32)
+			if err != nil {
+				fmt.Print(err.Error())
+				return nil
+			}
+
+			params := types.QueryParamsGetMMRProof{
+				Height: uint32(height),
+			}
+
+			queryData, err := cdc.MarshalJSON(params)
+			if err != nil {
+				fmt.Print(err.Error())
+				return nil
+			}
+
+			res, _, err := cliCtx.QueryWithData("custom/relay/getmmrproof", queryData)
+
+			if err != nil {
+				fmt.Printf("could not get MMR proof at height %s \n", args[0])
+				return nil
+			}
+
+			var out types.QueryResGetMMRProof
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(&out)
+		},
+	}
+}
+
+// GetCmdGetHeaders returns the CLI command struct for getHeaders
+func GetCmdGetHeaders(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "getheaders <digest or height> [count]",
+		Example: "getheaders 606210 100",
+		Long:    "Get up to [count] consecutive best chain headers, starting at a best chain digest or a height. [count] defaults to the default lookup limit",
+		Args:    cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			var count uint32
+			if len(args) == 2 {
+				c, err := strconv.ParseUint(args[1], 10, 32)
+				if err != nil {
+					fmt.Print(err.Error())
+					return nil
+				}
+				count = uint32(c)
+			}
+
+			params := types.QueryParamsGetHeaders{
+				Count: count,
+			}
+			height, err := strconv.ParseUint(args[0], 10, 32)
+			if err == nil {
+				params.Height = uint32(height)
+			} else {
+				digestLE, sdkErr := types.Hash256DigestFromHex(args[0])
+				if sdkErr != nil {
+					fmt.Print(sdkErr.Error())
+					return nil
+				}
+				params.DigestLE = digestLE
+			}
+
+			queryData, err := cdc.MarshalJSON(params)
+			if err != nil {
+				fmt.Print(err.Error())
+				return nil
+			}
+
+			res, _, err := cliCtx.QueryWithData("custom/relay/getheaders", queryData)
+
+			if err != nil {
+				fmt.Printf("could not get headers from %s \n", args[0])
+				return nil
+			}
+
+			var out types.QueryResGetHeaders
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(&out)
+		},
+	}
+}
+
+// GetCmdLocateHeaders returns the CLI command struct for locateHeaders
+func GetCmdLocateHeaders(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "locateheaders <json list of digests> [count]",
+		Example: "locateheaders '[\"0x4c2078d0388e3844fe6241723e9543074bd3a974c16611000000000000000000\"]' 100",
+		Long:    "Find the highest digest of a block locator on the best chain, and get up to [count] best chain headers after it. [count] defaults to the default lookup limit",
+		Args:    cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			var count uint32
+			if len(args) == 2 {
+				c, err := strconv.ParseUint(args[1], 10, 32)
+				if err != nil {
+					fmt.Print(err.Error())
+					return nil
+				}
+				count = uint32(c)
+			}
+
+			var locator []types.Hash256Digest
+			jsonErr := json.Unmarshal([]byte(args[0]), &locator)
+			if jsonErr != nil {
+				return jsonErr
+			}
+
+			params := types.QueryParamsLocateHeaders{
+				Locator: locator,
+				Count:   count,
+			}
+
+			queryData, err := cdc.MarshalJSON(params)
+			if err != nil {
+				fmt.Print(err.Error())
+				return nil
+			}
+
+			res, _, err := cliCtx.QueryWithData("custom/relay/locateheaders", queryData)
+
+			if err != nil {
+				fmt.Println("could not locate the headers")
+				return nil
+			}
+
+			var out types.QueryResLocateHeaders
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(&out)
+		},
+	}
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"encoding/json"
+	"io/ioutil"
 	"strconv"
 
 	"github.com/spf13/cobra"
@@ -13,6 +14,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/cosmos/cosmos-sdk/x/gov"
 
 	"github.com/summa-tx/relays/golang/x/relay/types"
 
@@ -35,6 +37,7 @@ func GetTxCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 		GetCmdNewRequest(cdc),
 		GetCmdProvideProof(cdc),
 		GetCmdMarkNewHeaviest(cdc),
+		GetCmdCancelRequest(cdc),
 	)...)
 
 	return relayTxCmd
@@ -146,12 +149,12 @@ func GetCmdIngestDifficultyChange(cdc *codec.Codec) *cobra.Command {
 
 // GetCmdNewRequest stores a new proof request
 func GetCmdNewRequest(cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
-		Use:     "newrequest <spends> <pays> <value> <numConfs>",
-		Example: "newrequest 0x 17a91423737cd98bb6b2da5a11bcd82e5de36591d69f9f87 0 1 --from me",
+	cmd := &cobra.Command{
+		Use:     "newrequest <spends> <pays> <value> <numConfs> [maxFills] [expiryHeight] [btcExpiryHeight]",
+		Example: "newrequest 0x 17a91423737cd98bb6b2da5a11bcd82e5de36591d69f9f87 0 1 3 --bounty 10stake --from me",
 		Short:   "Stores a new proof request",
-		Long:    "Stores a new proof request",
-		Args:    cobra.ExactArgs(4),
+		Long:    "Stores a new proof request.\nThe request closes after maxFills proofs. It defaults to 1.\nThe request also closes after the Cosmos block at expiryHeight, or once the\nbest Bitcoin block is above btcExpiryHeight. 0 means no expiry.\nUse flag --bounty to pay the prover for each fill. The bounty is escrowed\nmaxFills times, and the rest is refunded if the request is cancelled or expires.",
+		Args:    cobra.RangeArgs(4, 7),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
@@ -167,6 +170,34 @@ func GetCmdNewRequest(cdc *codec.Codec) *cobra.Command {
 			if confsErr != nil {
 				return confsErr
 			}
+			var maxFills uint64
+			if len(args) >= 5 {
+				var fillsErr error
+				maxFills, fillsErr = strconv.ParseUint(args[4], 10, 32)
+				if fillsErr != nil {
+					return fillsErr
+				}
+			}
+			var expiryHeight int64
+			if len(args) >= 6 {
+				var expiryErr error
+				expiryHeight, expiryErr = strconv.ParseInt(args[5], 10, 64)
+				if expiryErr != nil {
+					return expiryErr
+				}
+			}
+			var btcExpiryHeight uint64
+			if len(args) == 7 {
+				var expiryErr error
+				btcExpiryHeight, expiryErr = strconv.ParseUint(args[6], 10, 32)
+				if expiryErr != nil {
+					return expiryErr
+				}
+			}
+			bounty, bountyErr := sdk.ParseCoins(viper.GetString("bounty"))
+			if bountyErr != nil {
+				return bountyErr
+			}
 
 			msg := types.NewMsgNewRequest(
 				cliCtx.GetFromAddress(),
@@ -176,6 +207,12 @@ func GetCmdNewRequest(cdc *codec.Codec) *cobra.Command {
 				uint8(numConfs),
 				types.Local,
 				nil,
+				types.RequestOptions{
+					MaxFills:        uint32(maxFills),
+					ExpiryHeight:    expiryHeight,
+					BTCExpiryHeight: uint32(btcExpiryHeight),
+					Bounty:          bounty,
+				},
 			)
 			err := msg.ValidateBasic()
 			if err != nil {
@@ -186,6 +223,9 @@ func GetCmdNewRequest(cdc *codec.Codec) *cobra.Command {
 
 		},
 	}
+
+	attachFlagBounty(cmd)
+	return cmd
 }
 
 // GetCmdProvideProof stores a new proof request
@@ -306,3 +346,107 @@ func GetCmdMarkNewHeaviest(cdc *codec.Codec) *cobra.Command {
 		},
 	}
 }
+
+// GetCmdCancelRequest cancels a proof request created by the sender
+func GetCmdCancelRequest(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "cancelrequest <id>",
+		Example: "cancelrequest 12 --from me",
+		Short:   "Cancels a proof request",
+		Long:    "Cancels a proof request. Only the creator of the request may cancel it.\nID can be an \"0x\" prepended hexbyte string or an integer",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			id, err := types.RequestIDFromString(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgCancelRequest(
+				cliCtx.GetFromAddress(),
+				id,
+			)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// CheckpointProposalJSON is the contents of a checkpoint proposal file
+type CheckpointProposalJSON struct {
+	Title        string             `json:"title"`
+	Description  string             `json:"description"`
+	Add          []types.Checkpoint `json:"add"`
+	Remove       []uint32           `json:"remove"`
+	RaiseGenesis uint32             `json:"raiseGenesis"`
+	Deposit      sdk.Coins          `json:"deposit"`
+}
+
+// GetCmdSubmitCheckpointProposal creates a CLI command to propose checkpoint changes
+func GetCmdSubmitCheckpointProposal(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "relay-checkpoint <path/to/proposal.json>",
+		Example: "relay-checkpoint proposal.json --from me",
+		Short:   "Submit a relay checkpoint proposal",
+		Long: `Submit a proposal to add or remove relay checkpoints, and optionally raise the relay genesis to a checkpoint, along with an initial deposit.
+The proposal file looks like:
+
+{
+  "title": "Checkpoint block 606210",
+  "description": "Pin the relay to block 606210",
+  "add": [
+    {
+      "height": 606210,
+      "digest": "0x4c2078d0388e3844fe6241723e9543074bd3a974c16611000000000000000000"
+    }
+  ],
+  "remove": [],
+  "raiseGenesis": 606210,
+  "deposit": [
+    {
+      "denom": "stake",
+      "amount": "10000"
+    }
+  ]
+}`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			contents, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			var proposal CheckpointProposalJSON
+			err = cdc.UnmarshalJSON(contents, &proposal)
+			if err != nil {
+				return err
+			}
+
+			content := types.NewCheckpointProposal(
+				proposal.Title,
+				proposal.Description,
+				proposal.Add,
+				proposal.Remove,
+				proposal.RaiseGenesis,
+			)
+
+			msg := gov.NewMsgSubmitProposal(content, proposal.Deposit, cliCtx.GetFromAddress())
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
@@ -21,3 +21,7 @@ func readJSONFromFile(filename string) ([]byte, error) {
 func attachFlagFileinput(cmd *cobra.Command) {
 	cmd.Flags().Bool("inputfile", false, "Accepts a file as input for each json parameter")
 }
+
+func attachFlagBounty(cmd *cobra.Command) {
+	cmd.Flags().String("bounty", "", "Coins paid to the prover for each fill, e.g. 10stake")
+}
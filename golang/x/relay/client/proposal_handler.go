@@ -0,0 +1,10 @@
+package client
+
+import (
+	govclient "github.com/cosmos/cosmos-sdk/x/gov/client"
+	"github.com/summa-tx/relays/golang/x/relay/client/cli"
+	"github.com/summa-tx/relays/golang/x/relay/client/rest"
+)
+
+// ProposalHandler is the relay checkpoint proposal handler
+var ProposalHandler = govclient.NewProposalHandler(cli.GetCmdSubmitCheckpointProposal, rest.ProposalRESTHandler)
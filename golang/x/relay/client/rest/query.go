@@ -303,6 +303,39 @@ func getRequestHandler(cliCtx context.CLIContext, storeName string) http.Handler
 	}
 }
 
+// handler function for getProvenTx queries. parses arguments from url string, and passes them through
+// as a QueryParamsGetProvenTx struct
+func getProvenTxHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		txid, sdkErr := types.Hash256DigestFromHex(vars["txid"])
+		if sdkErr != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, sdkErr.Error())
+			return
+		}
+
+		params := types.QueryParamsGetProvenTx{
+			TxID: txid,
+		}
+
+		queryData, err := json.Marshal(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData("custom/relay/getproventx", queryData)
+
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
 // struct to help parse json parameters since checkRequests has params more complex than
 // other view functions and hence technically comes in as a POST request w/ json params
 type checkRequestsReq struct {
@@ -383,3 +416,332 @@ func checkProofHandler(cliCtx context.CLIContext, storeName string) http.Handler
 		rest.PostProcessResponse(w, cliCtx, res)
 	}
 }
+
+// handler function for getHeader queries. parses arguments from url string, and passes them through
+// as a QueryParamsGetHeader struct
+func getHeaderHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		digestLE, sdkErr := types.Hash256DigestFromHex(vars["digest"])
+		if sdkErr != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, sdkErr.Error())
+			return
+		}
+
+		params := types.QueryParamsGetHeader{
+			DigestLE: digestLE,
+		}
+
+		queryData, err := json.Marshal(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData("custom/relay/getheader", queryData)
+
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// handler function for getHeaderByHeight queries. parses arguments from url string, and passes them through
+// as a QueryParamsGetHeaderByHeight struct
+func getHeaderByHeightHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		height, err := strconv.ParseUint(vars["height"], 10, 32)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		params := types.QueryParamsGetHeaderByHeight{
+			Height: uint32(height),
+		}
+
+		queryData, err := json.Marshal(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData("custom/relay/getheaderbyheight", queryData)
+
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// handler function for getChainWork queries. parses arguments from url string, and passes them through
+// as a QueryParamsGetChainWork struct
+func getChainWorkHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		digestLE, sdkErr := types.Hash256DigestFromHex(vars["digest"])
+		if sdkErr != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, sdkErr.Error())
+			return
+		}
+
+		params := types.QueryParamsGetChainWork{
+			DigestLE: digestLE,
+		}
+
+		queryData, err := json.Marshal(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData("custom/relay/getchainwork", queryData)
+
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// handler function for getCurrentEpochDifficulty queries
+func getCurrentEpochDifficultyHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, _, err := cliCtx.QueryWithData("custom/relay/getcurrentepochdifficulty", nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// handler function for getPrevEpochDifficulty queries
+func getPrevEpochDifficultyHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, _, err := cliCtx.QueryWithData("custom/relay/getprevepochdifficulty", nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// handler function for findHeight queries. parses arguments from url string, and passes them through
+// as a QueryParamsFindHeight struct
+func findHeightHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		digestLE, sdkErr := types.Hash256DigestFromHex(vars["digest"])
+		if sdkErr != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, sdkErr.Error())
+			return
+		}
+
+		params := types.QueryParamsFindHeight{
+			DigestLE: digestLE,
+		}
+
+		queryData, err := json.Marshal(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData("custom/relay/findheight", queryData)
+
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// handler function for getChainParams queries
+func getChainParamsHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, _, err := cliCtx.QueryWithData("custom/relay/getchainparams", nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// handler function for params queries
+func paramsHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, _, err := cliCtx.QueryWithData("custom/relay/params", nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// handler function for getTips queries
+func getTipsHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, _, err := cliCtx.QueryWithData("custom/relay/gettips", nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// handler function for getMMRRoot queries
+func getMMRRootHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, _, err := cliCtx.QueryWithData("custom/relay/getmmrroot", nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// handler function for getMMRProof queries. parses arguments from url string, and passes them through
+// as a QueryParamsGetMMRProof struct
+func getMMRProofHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		height, err := strconv.ParseUint(vars["height"], 10, 32)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		params := types.QueryParamsGetMMRProof{
+			Height: uint32(height),
+		}
+
+		queryData, err := json.Marshal(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData("custom/relay/getmmrproof", queryData)
+
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// handler function for getHeaders queries. parses arguments from url string, and passes them through
+// as a QueryParamsGetHeaders struct. start may be a digest or a height
+func getHeadersHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		var params types.QueryParamsGetHeaders
+		if val, ok := vars["count"]; ok {
+			count, err := strconv.ParseUint(val, 10, 32)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			params.Count = uint32(count)
+		}
+
+		height, err := strconv.ParseUint(vars["start"], 10, 32)
+		if err == nil {
+			params.Height = uint32(height)
+		} else {
+			digestLE, sdkErr := types.Hash256DigestFromHex(vars["start"])
+			if sdkErr != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, sdkErr.Error())
+				return
+			}
+			params.DigestLE = digestLE
+		}
+
+		queryData, err := json.Marshal(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData("custom/relay/getheaders", queryData)
+
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// struct to help parse json parameters since locateHeaders takes a list of
+// digests and hence technically comes in as a POST request w/ json params
+type locateHeadersReq struct {
+	Locator []types.Hash256Digest `json:"locator"`
+	Count   uint32                `json:"count"`
+}
+
+// handler function for locateHeaders queries. parses arguments from the request body, and passes them through
+// as a QueryParamsLocateHeaders struct
+// Comes in as POST request will proceed to treat it as a GET
+func locateHeadersHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req locateHeadersReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		params := types.QueryParamsLocateHeaders{
+			Locator: req.Locator,
+			Count:   req.Count,
+		}
+
+		queryData, err := json.Marshal(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData("custom/relay/locateheaders", queryData)
+
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
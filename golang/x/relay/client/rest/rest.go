@@ -18,6 +18,7 @@ func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, storeName string)
 	s.HandleFunc("/marknewheaviest", markNewHeaviestHandler(cliCtx)).Methods("POST")
 	s.HandleFunc("/newrequest", newRequestHandler(cliCtx)).Methods("POST")
 	s.HandleFunc("/provideproof", provideProofHandler(cliCtx)).Methods("POST")
+	s.HandleFunc("/cancelrequest", cancelRequestHandler(cliCtx)).Methods("POST")
 
 	// add new query routes below
 	// {} denotes variable parts of the url route
@@ -33,6 +34,21 @@ func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, storeName string)
 	s.HandleFunc("/heaviestfromancestor/{ancestor}/{currentbest}/{newbest}/", heaviestFromAncestorHandler(cliCtx, storeName)).Methods("GET")
 	s.HandleFunc("/heaviestfromancestor/{ancestor}/{currentbest}/{newbest}/{limit}", heaviestFromAncestorHandler(cliCtx, storeName)).Methods("GET")
 	s.HandleFunc("/getrequest/{id}", getRequestHandler(cliCtx, storeName)).Methods("GET")
+	s.HandleFunc("/getproventx/{txid}", getProvenTxHandler(cliCtx, storeName)).Methods("GET")
+	s.HandleFunc("/getheader/{digest}", getHeaderHandler(cliCtx, storeName)).Methods("GET")
+	s.HandleFunc("/getheaderbyheight/{height}", getHeaderByHeightHandler(cliCtx, storeName)).Methods("GET")
+	s.HandleFunc("/getchainwork/{digest}", getChainWorkHandler(cliCtx, storeName)).Methods("GET")
+	s.HandleFunc("/getcurrentepochdifficulty", getCurrentEpochDifficultyHandler(cliCtx, storeName)).Methods("GET")
+	s.HandleFunc("/getprevepochdifficulty", getPrevEpochDifficultyHandler(cliCtx, storeName)).Methods("GET")
+	s.HandleFunc("/findheight/{digest}", findHeightHandler(cliCtx, storeName)).Methods("GET")
+	s.HandleFunc("/getchainparams", getChainParamsHandler(cliCtx, storeName)).Methods("GET")
+	s.HandleFunc("/gettips", getTipsHandler(cliCtx, storeName)).Methods("GET")
+	s.HandleFunc("/getmmrroot", getMMRRootHandler(cliCtx, storeName)).Methods("GET")
+	s.HandleFunc("/getmmrproof/{height}", getMMRProofHandler(cliCtx, storeName)).Methods("GET")
+	s.HandleFunc("/getheaders/{start}/", getHeadersHandler(cliCtx, storeName)).Methods("GET")
+	s.HandleFunc("/getheaders/{start}/{count}", getHeadersHandler(cliCtx, storeName)).Methods("GET")
+	s.HandleFunc("/params", paramsHandler(cliCtx, storeName)).Methods("GET")
 	s.HandleFunc("/checkrequests", checkRequestsHandler(cliCtx, storeName)).Methods("POST") // technically a view only query, POST is due to complex params
 	s.HandleFunc("/checkproof", checkProofHandler(cliCtx, storeName)).Methods("POST")       // technically a view only query, POST is due to complex params
+	s.HandleFunc("/locateheaders", locateHeadersHandler(cliCtx, storeName)).Methods("POST") // technically a view only query, POST is due to complex params
 }
@@ -6,6 +6,8 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/context"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	govrest "github.com/cosmos/cosmos-sdk/x/gov/client/rest"
 
 	"github.com/cosmos/cosmos-sdk/types/rest"
 	"github.com/summa-tx/relays/golang/x/relay/types"
@@ -131,12 +133,16 @@ func markNewHeaviestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 
 // NewRequestReq is the request struct for a new proof request
 type NewRequestReq struct {
-	BaseReq   rest.BaseReq `json:"base_req"`
-	Spends    []byte       `json:"spends"`
-	Pays      []byte       `json:"pays"`
-	PaysValue uint64       `json:"paysValue"`
-	NumConfs  uint8        `json:"numConfs"`
-	Sender    string       `json:"sender"`
+	BaseReq         rest.BaseReq `json:"base_req"`
+	Spends          []byte       `json:"spends"`
+	Pays            []byte       `json:"pays"`
+	PaysValue       uint64       `json:"paysValue"`
+	NumConfs        uint8        `json:"numConfs"`
+	MaxFills        uint32       `json:"maxFills"`
+	ExpiryHeight    int64        `json:"expiryHeight"`
+	BTCExpiryHeight uint32       `json:"btcExpiryHeight"`
+	Bounty          sdk.Coins    `json:"bounty"`
+	Sender          string       `json:"sender"`
 }
 
 func newRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
@@ -159,7 +165,12 @@ func newRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		msg := types.NewMsgNewRequest(addr, req.Spends, req.Pays, req.PaysValue, req.NumConfs, types.Local, nil)
+		msg := types.NewMsgNewRequest(addr, req.Spends, req.Pays, req.PaysValue, req.NumConfs, types.Local, nil, types.RequestOptions{
+			MaxFills:        req.MaxFills,
+			ExpiryHeight:    req.ExpiryHeight,
+			BTCExpiryHeight: req.BTCExpiryHeight,
+			Bounty:          req.Bounty,
+		})
 		err = msg.ValidateBasic()
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -210,3 +221,95 @@ func provideProofHandler(cliCtx context.CLIContext) http.HandlerFunc {
 		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
 	}
 }
+
+// CancelRequestReq is the request struct for cancelling a proof request
+type CancelRequestReq struct {
+	BaseReq rest.BaseReq    `json:"base_req"`
+	ID      types.RequestID `json:"id"`
+	Sender  string          `json:"sender"`
+}
+
+func cancelRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req CancelRequestReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		addr, err := sdk.AccAddressFromBech32(req.Sender)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgCancelRequest(addr, req.ID)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+// CheckpointProposalReq is the request struct for a checkpoint proposal
+type CheckpointProposalReq struct {
+	BaseReq      rest.BaseReq       `json:"base_req"`
+	Title        string             `json:"title"`
+	Description  string             `json:"description"`
+	Add          []types.Checkpoint `json:"add"`
+	Remove       []uint32           `json:"remove"`
+	RaiseGenesis uint32             `json:"raiseGenesis"`
+	Deposit      sdk.Coins          `json:"deposit"`
+	Proposer     string             `json:"proposer"`
+}
+
+// ProposalRESTHandler exposes the checkpoint proposal handler under the
+// gov proposals REST route
+func ProposalRESTHandler(cliCtx context.CLIContext) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "relay_checkpoint",
+		Handler:  checkpointProposalHandler(cliCtx),
+	}
+}
+
+func checkpointProposalHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req CheckpointProposalReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		addr, err := sdk.AccAddressFromBech32(req.Proposer)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		content := types.NewCheckpointProposal(req.Title, req.Description, req.Add, req.Remove, req.RaiseGenesis)
+
+		msg := gov.NewMsgSubmitProposal(content, req.Deposit, addr)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
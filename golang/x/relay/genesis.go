@@ -2,25 +2,84 @@ package relay
 
 import (
 	"errors"
+	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	btcspv "github.com/summa-tx/bitcoin-spv/golang/btcspv"
 	abci "github.com/tendermint/tendermint/abci/types"
 )
 
-// GenesisState is the genesis state
+// GenesisState is the genesis state. A new relay is started from a chain of
+// Headers and the PeriodStart of their epoch. An exported relay instead
+// carries its full store in State, and Headers and PeriodStart are unused.
+// Network selects the Bitcoin network the relay follows. Checkpoints pin
+// best chain blocks, and apply to the genesis headers. Params are the
+// relay's limits, and default if unset
 type GenesisState struct {
+	Network     Network         `json:"network"`
+	Params      Params          `json:"params"`
 	Headers     []BitcoinHeader `json:"headers"`
 	PeriodStart BitcoinHeader   `json:"periodStart"`
+	Checkpoints []Checkpoint    `json:"checkpoints"`
+	State       *ChainState     `json:"state"`
 }
 
 // NewGenesisState instantiates a genesis state
-func NewGenesisState(headers []BitcoinHeader, periodStart BitcoinHeader) GenesisState {
-	return GenesisState{Headers: headers, PeriodStart: periodStart}
+func NewGenesisState(network Network, headers []BitcoinHeader, periodStart BitcoinHeader) GenesisState {
+	return GenesisState{Network: network, Headers: headers, PeriodStart: periodStart}
+}
+
+// genesisChainParams returns the chain parameters for a genesis state.
+// Genesis files written before networks were configurable follow mainnet
+func genesisChainParams(data GenesisState) (ChainParams, error) {
+	network := data.Network
+	if network == "" {
+		network = Mainnet
+	}
+	params, err := ChainParamsForNetwork(network)
+	if err != nil {
+		return ChainParams{}, err
+	}
+	return params, nil
+}
+
+// genesisParams returns the relay params for a genesis state. Genesis files
+// written before params were introduced use the defaults
+func genesisParams(data GenesisState) Params {
+	if data.Params == (Params{}) {
+		return DefaultParams()
+	}
+	return data.Params
 }
 
 // ValidateGenesis validates a genesis state
 func ValidateGenesis(data GenesisState) error {
+	params, err := genesisChainParams(data)
+	if err != nil {
+		return err
+	}
+
+	err = genesisParams(data).Validate()
+	if err != nil {
+		return err
+	}
+
+	checkpoints := make(map[uint32]Hash256Digest)
+	for _, c := range data.Checkpoints {
+		if _, ok := checkpoints[c.Height]; ok {
+			return fmt.Errorf("more than one checkpoint at height %d", c.Height)
+		}
+		checkpoints[c.Height] = c.Digest
+	}
+
+	if data.State != nil {
+		return data.State.Validate()
+	}
+
+	if len(data.Headers) == 0 {
+		return errors.New("genesis state must include at least 1 header")
+	}
+
 	raw := []byte{}
 	for _, header := range data.Headers {
 		_, err := header.Validate()
@@ -28,15 +87,20 @@ func ValidateGenesis(data GenesisState) error {
 			return err
 		}
 		raw = append(raw, header.Raw[:]...)
+
+		if digest, ok := checkpoints[header.Height]; ok && digest != header.Hash {
+			return fmt.Errorf("header %x conflicts with checkpoint %x at height %d", header.Hash, digest, header.Height)
+		}
 	}
 
-	_, err := btcspv.ValidateHeaderChain(raw)
+	_, err = btcspv.ValidateHeaderChain(raw)
 	if err != nil {
 		return err
 	}
 
 	// Genesis state must include first block of an epoch plus another block belonging to that same epoch
-	if data.PeriodStart.Height != (data.Headers[0].Height - (data.Headers[0].Height % 2016)) {
+	interval := params.RetargetInterval
+	if data.PeriodStart.Height != (data.Headers[0].Height - (data.Headers[0].Height % interval)) {
 		return errors.New("period start has incorrect height")
 	}
 
@@ -47,6 +111,8 @@ func ValidateGenesis(data GenesisState) error {
 func DefaultGenesisState() GenesisState {
 	periodStart, headers := getGenesisHeaders()
 	return GenesisState{
+		Network:     Mainnet,
+		Params:      DefaultParams(),
 		Headers:     headers,
 		PeriodStart: periodStart,
 	}
@@ -54,6 +120,24 @@ func DefaultGenesisState() GenesisState {
 
 // InitGenesis inits the app state based on the genesis state
 func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) []abci.ValidatorUpdate {
+	params, paramsErr := genesisChainParams(data)
+	if paramsErr != nil {
+		panic("Bad network in genesis state! " + paramsErr.Error())
+	}
+	keeper.SetChainParams(ctx, params)
+	keeper.SetParams(ctx, genesisParams(data))
+	for _, checkpoint := range data.Checkpoints {
+		keeper.SetCheckpoint(ctx, checkpoint)
+	}
+
+	if data.State != nil {
+		err := keeper.ImportChainState(ctx, *data.State)
+		if err != nil {
+			panic("Bad chain state in genesis state! " + err.Error())
+		}
+		return []abci.ValidatorUpdate{}
+	}
+
 	err := keeper.SetGenesisState(ctx, data.Headers[0], data.PeriodStart)
 	if err != nil {
 		panic("already init!")
@@ -68,8 +152,22 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) []abci.Valid
 }
 
 // ExportGenesis exports the genesis state
-// TODO: export GenesisState
-//       May need special store keys for it
 func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
-	panic("Not implemented")
+	// A store written by an older version of the module is migrated before
+	// it is exported. Upgrading is an export with the new version, followed
+	// by a restart from the exported genesis
+	err := k.Migrate(ctx)
+	if err != nil {
+		panic("Could not migrate relay store! " + err.Error())
+	}
+	state, err := k.ExportChainState(ctx)
+	if err != nil {
+		panic("Could not export relay state! " + err.Error())
+	}
+	return GenesisState{
+		Network:     k.GetChainParams(ctx).Network,
+		Params:      k.GetParams(ctx),
+		Checkpoints: k.GetAllCheckpoints(ctx),
+		State:       &state,
+	}
 }
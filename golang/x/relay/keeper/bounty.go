@@ -0,0 +1,67 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/summa-tx/relays/golang/x/relay/types"
+)
+
+// escrowAmount returns the bounty multiplied by the number of fills
+func escrowAmount(bounty sdk.Coins, fills uint32) sdk.Coins {
+	escrow := sdk.Coins{}
+	for _, coin := range bounty {
+		escrow = append(escrow, sdk.NewCoin(coin.Denom, coin.Amount.MulRaw(int64(fills))))
+	}
+	return escrow
+}
+
+// escrowBounty moves a new request's bounty, once for each fill it allows,
+// from its creator to the module account. It returns the escrowed coins
+func (k Keeper) escrowBounty(ctx sdk.Context, creator sdk.AccAddress, bounty sdk.Coins, maxFills uint32) (sdk.Coins, sdk.Error) {
+	if bounty.IsZero() {
+		return nil, nil
+	}
+	escrow := escrowAmount(bounty, maxFills)
+	err := k.supplyKeeper.SendCoinsFromAccountToModule(ctx, creator, types.ModuleName, escrow)
+	if err != nil {
+		return nil, err
+	}
+	return escrow, nil
+}
+
+// canPayBounty checks whether a request's escrow holds its bounty. Bounties
+// paid for fills that are later orphaned are not recovered, so a reopened
+// request may run out of escrow
+func canPayBounty(request types.ProofRequest) bool {
+	return request.Bounty.IsZero() || request.Escrow.IsAllGTE(request.Bounty)
+}
+
+// payBounty pays a request's bounty to the prover out of its escrow. It
+// errors if the escrow has run out, rather than fill the request unpaid
+func (k Keeper) payBounty(ctx sdk.Context, prover sdk.AccAddress, id types.RequestID, request *types.ProofRequest) sdk.Error {
+	if request.Bounty.IsZero() {
+		return nil
+	}
+	if !canPayBounty(*request) {
+		return types.ErrInsufficientEscrow(types.DefaultCodespace, request.Escrow.String(), request.Bounty.String(), id)
+	}
+	err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, prover, request.Bounty)
+	if err != nil {
+		return err
+	}
+	request.Escrow = request.Escrow.Sub(request.Bounty)
+	return nil
+}
+
+// refundEscrow returns the rest of a request's escrow to its creator
+func (k Keeper) refundEscrow(ctx sdk.Context, request *types.ProofRequest) sdk.Error {
+	if request.Escrow.IsZero() {
+		return nil
+	}
+	err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, request.Creator, request.Escrow)
+	if err != nil {
+		return err
+	}
+	request.Escrow = nil
+	return nil
+}
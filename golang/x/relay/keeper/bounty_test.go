@@ -0,0 +1,115 @@
+package keeper
+
+import (
+	"bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+	"github.com/summa-tx/relays/golang/x/relay/types"
+)
+
+func (s *KeeperSuite) moduleCoins() sdk.Coins {
+	return s.BankKeeper.GetCoins(s.Context, supply.NewModuleAddress(types.ModuleName))
+}
+
+func (s *KeeperSuite) TestEscrowAmount() {
+	bounty := sdk.NewCoins(sdk.NewInt64Coin("atom", 2), sdk.NewInt64Coin("stake", 10))
+	s.Equal(sdk.NewCoins(sdk.NewInt64Coin("atom", 6), sdk.NewInt64Coin("stake", 30)), escrowAmount(bounty, 3))
+	s.Equal(0, len(escrowAmount(nil, 3)))
+}
+
+func (s *KeeperSuite) TestBounty() {
+	creator := getAccAddress()
+	prover := sdk.AccAddress(bytes.Repeat([]byte{1}, 20))
+	bounty := sdk.NewCoins(sdk.NewInt64Coin("stake", 10))
+	_, err := s.BankKeeper.AddCoins(s.Context, creator, sdk.NewCoins(sdk.NewInt64Coin("stake", 100)))
+	s.SDKNil(err)
+
+	// errors if the creator can not pay the escrow
+	err = s.Keeper.setRequest(s.Context, creator, []byte{}, []byte{}, 0, 0, types.Local, nil, types.RequestOptions{MaxFills: 11, Bounty: bounty})
+	s.Equal(sdk.CodeInsufficientCoins, err.Code())
+	s.False(s.Keeper.hasRequest(s.Context, types.RequestID{}))
+
+	// escrows the bounty once for each fill
+	err = s.Keeper.setRequest(s.Context, creator, []byte{}, []byte{}, 0, 0, types.Local, nil, types.RequestOptions{MaxFills: 3, Bounty: bounty})
+	s.SDKNil(err)
+	request, err := s.Keeper.getRequest(s.Context, types.RequestID{})
+	s.SDKNil(err)
+	s.Equal(bounty, request.Bounty)
+	s.Equal(sdk.NewCoins(sdk.NewInt64Coin("stake", 30)), request.Escrow)
+	s.Equal(sdk.NewCoins(sdk.NewInt64Coin("stake", 70)), s.BankKeeper.GetCoins(s.Context, creator))
+	s.Equal(sdk.NewCoins(sdk.NewInt64Coin("stake", 30)), s.moduleCoins())
+
+	// pays the prover for each fill
+	filled := types.FilledRequests{
+		Proof:  types.SPVProof{TxID: types.Hash256Digest{1}},
+		Filled: []types.FilledRequestInfo{{ID: types.RequestID{}}},
+	}
+	s.SDKNil(s.Keeper.fillRequests(s.Context, prover, filled))
+	request, err = s.Keeper.getRequest(s.Context, types.RequestID{})
+	s.SDKNil(err)
+	s.Equal(sdk.NewCoins(sdk.NewInt64Coin("stake", 20)), request.Escrow)
+	s.Equal(bounty, s.BankKeeper.GetCoins(s.Context, prover))
+
+	// refunds the rest of the escrow on cancel
+	s.SDKNil(s.Keeper.CancelRequest(s.Context, creator, types.RequestID{}))
+	request, err = s.Keeper.getRequest(s.Context, types.RequestID{})
+	s.SDKNil(err)
+	s.Equal(0, len(request.Escrow))
+	s.Equal(sdk.NewCoins(sdk.NewInt64Coin("stake", 90)), s.BankKeeper.GetCoins(s.Context, creator))
+	s.Equal(0, len(s.moduleCoins()))
+}
+
+func (s *KeeperSuite) TestBountyReopened() {
+	creator := getAccAddress()
+	prover := sdk.AccAddress(bytes.Repeat([]byte{1}, 20))
+	bounty := sdk.NewCoins(sdk.NewInt64Coin("stake", 10))
+	_, err := s.BankKeeper.AddCoins(s.Context, creator, bounty.Add(bounty))
+	s.SDKNil(err)
+	s.SDKNil(s.Keeper.setRequest(s.Context, creator, []byte{}, []byte{}, 0, 0, types.Local, nil, types.RequestOptions{MaxFills: 2, Bounty: bounty}))
+
+	filled := types.FilledRequests{
+		Proof:  types.SPVProof{TxID: types.Hash256Digest{1}, ConfirmingHeader: types.BitcoinHeader{Hash: types.Hash256Digest{1}}},
+		Filled: []types.FilledRequestInfo{{ID: types.RequestID{}}},
+	}
+	s.SDKNil(s.Keeper.fillRequests(s.Context, prover, filled))
+	s.SDKNil(s.Keeper.reopenOrphanedFills(s.Context, []types.Hash256Digest{{1}}))
+
+	// the orphaned fill's bounty is not recovered, so the escrow pays one
+	// more fill, not two
+	filled.Proof.TxID = types.Hash256Digest{2}
+	s.SDKNil(s.Keeper.fillRequests(s.Context, prover, filled))
+	s.Equal(bounty.Add(bounty), s.BankKeeper.GetCoins(s.Context, prover))
+
+	// errors rather than fill the request unpaid
+	filled.Proof.TxID = types.Hash256Digest{3}
+	err = s.Keeper.fillRequests(s.Context, prover, filled)
+	s.Equal(sdk.CodeType(types.InsufficientEscrow), err.Code())
+	request, err := s.Keeper.getRequest(s.Context, types.RequestID{})
+	s.SDKNil(err)
+	s.Equal(uint32(1), request.NumFills)
+	s.True(request.ActiveState)
+
+	// a request closed at its fill limit is not reopened without escrow
+	request.NumFills = 2
+	request.ActiveState = false
+	s.SDKNil(s.Keeper.storeRequest(s.Context, types.RequestID{}, request))
+	s.SDKNil(s.Keeper.reopenOrphanedFills(s.Context, []types.Hash256Digest{{1}}))
+	request, err = s.Keeper.getRequest(s.Context, types.RequestID{})
+	s.SDKNil(err)
+	s.False(request.ActiveState)
+}
+
+func (s *KeeperSuite) TestBountyExpired() {
+	creator := getAccAddress()
+	bounty := sdk.NewCoins(sdk.NewInt64Coin("stake", 10))
+	_, err := s.BankKeeper.AddCoins(s.Context, creator, bounty)
+	s.SDKNil(err)
+	s.SDKNil(s.Keeper.setRequest(s.Context, creator, []byte{}, []byte{}, 0, 0, types.Local, nil, types.RequestOptions{ExpiryHeight: 1, Bounty: bounty}))
+	s.Equal(0, len(s.BankKeeper.GetCoins(s.Context, creator)))
+
+	// refunds the escrow when the request expires
+	s.SDKNil(s.Keeper.ExpireRequests(s.Context.WithBlockHeight(1)))
+	s.Equal(bounty, s.BankKeeper.GetCoins(s.Context, creator))
+	s.Equal(0, len(s.moduleCoins()))
+}
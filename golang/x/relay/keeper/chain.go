@@ -89,6 +89,7 @@ func (k Keeper) IsMostRecentCommonAncestor(ctx sdk.Context, ancestor, left, righ
 }
 
 // HeaviestFromAncestor determines the heavier descendant of a common ancestor
+// by comparing the accumulated work of each chain
 func (k Keeper) HeaviestFromAncestor(ctx sdk.Context, ancestor, currentBest, newBest types.Hash256Digest, limit uint32) (types.Hash256Digest, sdk.Error) {
 	ancestorBlock, err := k.GetHeader(ctx, ancestor)
 	if err != nil {
@@ -111,38 +112,10 @@ func (k Keeper) HeaviestFromAncestor(ctx sdk.Context, ancestor, currentBest, new
 		return types.Hash256Digest{}, types.ErrBadHeight(types.DefaultCodespace, "newBest", newBest)
 	}
 
-	nextPeriodStartHeight := ancestorBlock.Height + 2016 - (ancestorBlock.Height % 2016)
-	leftInPeriod := leftBlock.Height < nextPeriodStartHeight
-	rightInPeriod := rightBlock.Height < nextPeriodStartHeight
-
-	/*
-		NB:
-		1. Left is in a new window, right is in the old window. Left is heavier
-		2. Right is in a new window, left is in the old window. Right is heavier
-		3. Both are in the same window, choose the higher one
-		4. They're in different new windows. Choose the heavier one
-	*/
-	if !leftInPeriod && rightInPeriod {
-		return leftBlock.Hash, nil
-	}
-	if leftInPeriod && !rightInPeriod {
-		return rightBlock.Hash, nil
-	}
-	if leftInPeriod && rightInPeriod {
-		if leftBlock.Height >= rightBlock.Height {
-			return leftBlock.Hash, nil
-		}
-		return rightBlock.Hash, nil
-	}
-
-	// if !leftInPeriod && !rightInPeriod
-	leftDiff := btcspv.ExtractDifficulty(leftBlock.Raw)
-	leftAccDiff := leftDiff.Mul(sdk.NewUint(uint64(leftBlock.Height % 2016)))
-
-	rightDiff := btcspv.ExtractDifficulty(rightBlock.Raw)
-	rightAccDiff := rightDiff.Mul(sdk.NewUint(uint64(rightBlock.Height % 2016)))
-
-	if leftAccDiff.GTE(rightAccDiff) {
+	// Prefer the current best on ties, as Bitcoin Core does
+	leftWork := k.getChainWork(ctx, leftBlock.Hash)
+	rightWork := k.getChainWork(ctx, rightBlock.Hash)
+	if leftWork.GTE(rightWork) {
 		return leftBlock.Hash, nil
 	}
 	return rightBlock.Hash, nil
@@ -153,8 +126,10 @@ func (k Keeper) MarkNewHeaviest(ctx sdk.Context, ancestor types.Hash256Digest, c
 	newBestDigest := btcspv.Hash256(newBest[:])
 	currentBestDigest := btcspv.Hash256(currentBest[:])
 
-	if limit > 2016 {
-		return types.ErrLimitTooHigh(types.DefaultCodespace, limit)
+	limit = k.lookupLimit(ctx, limit)
+	maxLimit := k.GetParams(ctx).MaxLookupLimit
+	if limit > maxLimit {
+		return types.ErrLimitTooHigh(types.DefaultCodespace, limit, maxLimit)
 	}
 
 	if !k.HasHeader(ctx, newBestDigest) {
@@ -184,8 +159,24 @@ func (k Keeper) MarkNewHeaviest(ctx sdk.Context, ancestor types.Hash256Digest, c
 	if getHeaderErr != nil {
 		return getHeaderErr
 	}
-	// extract difficulty
-	newDiff := btcspv.ExtractDifficulty(newBestHeader.Raw)
+
+	// HeaviestFromAncestor has already checked that these are in the store
+	ancestorHeader, _ := k.GetHeader(ctx, ancestor)
+	knownBestHeader, _ := k.GetHeader(ctx, knownBestDigest)
+
+	err = k.checkReorgAgainstCheckpoints(ctx, ancestorHeader, newBestHeader)
+	if err != nil {
+		return err
+	}
+
+	return k.markNewHeaviest(ctx, ancestorHeader, knownBestHeader, newBestHeader)
+}
+
+// markNewHeaviest moves the best known digest to newBest. The caller must
+// have checked that newBest is heavier and that ancestor is the LCA
+func (k Keeper) markNewHeaviest(ctx sdk.Context, ancestor, knownBest, newBest types.BitcoinHeader) sdk.Error {
+	// extract difficulty, skipping back over any min difficulty blocks
+	newDiff := btcspv.CalculateDifficulty(k.getRealTarget(ctx, k.GetChainParams(ctx), newBest))
 	// get currentEpochDifficulty
 	currentEpochDiff := k.getCurrentEpochDifficulty(ctx)
 	if newDiff != currentEpochDiff {
@@ -195,9 +186,50 @@ func (k Keeper) MarkNewHeaviest(ctx sdk.Context, ancestor types.Hash256Digest, c
 		}
 	}
 
-	k.setLastReorgLCA(ctx, ancestor)
-	k.setBestKnownDigest(ctx, newBestDigest)
-	k.emitReorg(ctx, knownBestDigest, newBestDigest, ancestor)
+	k.reindexBestChain(ctx, ancestor, knownBest, newBest)
+	k.updateMMR(ctx, ancestor, knownBest, newBest)
+
+	k.setLastReorgLCA(ctx, ancestor.Hash)
+	k.setBestKnownDigest(ctx, newBest.Hash)
+	k.emitReorg(ctx, knownBest.Hash, newBest.Hash, ancestor.Hash)
+
+	orphaned := k.getOrphaned(ctx, ancestor, knownBest)
+	err := k.reopenOrphanedFills(ctx, orphaned)
+	if err != nil {
+		return err
+	}
+	k.afterReorg(ctx, ancestor, knownBest, newBest, orphaned)
 
 	return nil
 }
+
+// advanceBestTip marks a newly ingested tip as the best known digest if it
+// has more accumulated work than the current best. Tips that do not rejoin
+// the best chain within limit blocks are left for MarkNewHeaviest
+func (k Keeper) advanceBestTip(ctx sdk.Context, tip types.BitcoinHeader, limit uint32) sdk.Error {
+	knownBestDigest, err := k.GetBestKnownDigest(ctx)
+	if err != nil {
+		// The relay has not been initialized
+		return nil
+	}
+	knownBest, err := k.GetHeader(ctx, knownBestDigest)
+	if err != nil {
+		return err
+	}
+
+	if k.getChainWork(ctx, knownBest.Hash).GTE(k.getChainWork(ctx, tip.Hash)) {
+		return nil
+	}
+
+	ancestor, found := k.findBestChainAncestor(ctx, tip, limit)
+	if !found {
+		return nil
+	}
+
+	// Chains that would orphan a checkpoint never become the best chain
+	if k.checkReorgAgainstCheckpoints(ctx, ancestor, tip) != nil {
+		return nil
+	}
+
+	return k.markNewHeaviest(ctx, ancestor, knownBest, tip)
+}
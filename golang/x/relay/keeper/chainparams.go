@@ -0,0 +1,28 @@
+package keeper
+
+import (
+	"github.com/summa-tx/relays/golang/x/relay/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SetChainParams sets the parameters of the Bitcoin network the relay follows.
+// It is called once at genesis
+func (k Keeper) SetChainParams(ctx sdk.Context, params types.ChainParams) {
+	store := k.getChainStore(ctx)
+	store.Set([]byte(types.ChainParamsStorage), k.cdc.MustMarshalBinaryBare(params))
+}
+
+// GetChainParams returns the parameters of the Bitcoin network the relay
+// follows. Relays initialized before chain parameters existed follow mainnet
+func (k Keeper) GetChainParams(ctx sdk.Context) types.ChainParams {
+	store := k.getChainStore(ctx)
+	buf := store.Get([]byte(types.ChainParamsStorage))
+	if buf == nil {
+		return types.MainnetParams()
+	}
+
+	var params types.ChainParams
+	k.cdc.MustUnmarshalBinaryBare(buf, &params)
+	return params
+}
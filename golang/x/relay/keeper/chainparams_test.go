@@ -0,0 +1,31 @@
+package keeper
+
+import (
+	"github.com/summa-tx/bitcoin-spv/golang/btcspv"
+	"github.com/summa-tx/relays/golang/x/relay/types"
+)
+
+func (s *KeeperSuite) TestGetChainParams() {
+	// defaults to mainnet before the params are set
+	s.Keeper.getChainStore(s.Context).Delete([]byte(types.ChainParamsStorage))
+	s.Equal(types.MainnetParams(), s.Keeper.GetChainParams(s.Context))
+
+	s.Keeper.SetChainParams(s.Context, types.RegtestParams())
+	s.Equal(types.RegtestParams(), s.Keeper.GetChainParams(s.Context))
+}
+
+func (s *KeeperSuite) TestRetargetAlgorithm() {
+	params := types.MainnetParams()
+	target := btcspv.ExtractTarget(s.Fixtures.ChainTestCases.IsMostRecentCA.Genesis.Raw)
+	timespan := uint(params.TargetTimespan())
+
+	// on schedule keeps the target
+	s.Equal(target, retargetAlgorithm(params, target, 0, timespan))
+	// adjustment is clamped to a factor of 4
+	s.Equal(target.QuoUint64(4), retargetAlgorithm(params, target, 0, 1))
+	s.Equal(target.MulUint64(4), retargetAlgorithm(params, target, 0, timespan*10))
+	// never easier than the pow limit
+	s.Equal(params.PowLimit, retargetAlgorithm(params, params.PowLimit, 0, timespan*2))
+	// regtest never retargets
+	s.Equal(target, retargetAlgorithm(types.RegtestParams(), target, 0, 1))
+}
@@ -0,0 +1,193 @@
+package keeper
+
+import (
+	"encoding/binary"
+
+	"github.com/summa-tx/bitcoin-spv/golang/btcspv"
+	"github.com/summa-tx/relays/golang/x/relay/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func (k Keeper) getCheckpointStore(ctx sdk.Context) sdk.KVStore {
+	return k.getPrefixStore(ctx, types.CheckpointStorePrefix)
+}
+
+// SetCheckpoint pins the best chain block at a height to a digest
+func (k Keeper) SetCheckpoint(ctx sdk.Context, checkpoint types.Checkpoint) {
+	store := k.getCheckpointStore(ctx)
+	store.Set(heightKey(checkpoint.Height), checkpoint.Digest[:])
+}
+
+// DeleteCheckpoint removes the checkpoint at a height
+func (k Keeper) DeleteCheckpoint(ctx sdk.Context, height uint32) sdk.Error {
+	store := k.getCheckpointStore(ctx)
+	if !store.Has(heightKey(height)) {
+		return types.ErrUnknownCheckpoint(types.DefaultCodespace, height)
+	}
+	store.Delete(heightKey(height))
+	return nil
+}
+
+// GetCheckpoint returns the digest checkpointed at a height
+func (k Keeper) GetCheckpoint(ctx sdk.Context, height uint32) (types.Hash256Digest, sdk.Error) {
+	store := k.getCheckpointStore(ctx)
+	buf := store.Get(heightKey(height))
+	if buf == nil {
+		return types.Hash256Digest{}, types.ErrUnknownCheckpoint(types.DefaultCodespace, height)
+	}
+
+	// Can only fail if data store is corrupt
+	digest, _ := btcspv.NewHash256Digest(buf)
+	return digest, nil
+}
+
+// GetAllCheckpoints returns every checkpoint, ordered by height
+func (k Keeper) GetAllCheckpoints(ctx sdk.Context) []types.Checkpoint {
+	return k.getCheckpointsInRange(ctx, 0, nil)
+}
+
+// getCheckpointsInRange returns the checkpoints from a height up to, but
+// excluding, an end key, ordered by height. A nil end has no upper bound
+func (k Keeper) getCheckpointsInRange(ctx sdk.Context, start uint32, end []byte) []types.Checkpoint {
+	store := k.getCheckpointStore(ctx)
+	iterator := store.Iterator(heightKey(start), end)
+	defer iterator.Close()
+
+	checkpoints := []types.Checkpoint{}
+	for ; iterator.Valid(); iterator.Next() {
+		// Can only fail if data store is corrupt
+		digest, _ := btcspv.NewHash256Digest(iterator.Value())
+		checkpoints = append(checkpoints, types.Checkpoint{
+			Height: binary.BigEndian.Uint32(iterator.Key()),
+			Digest: digest,
+		})
+	}
+	return checkpoints
+}
+
+// checkHeadersAgainstCheckpoints errors if a checkpoint exists at the height
+// of any header in a chain with a different digest
+func (k Keeper) checkHeadersAgainstCheckpoints(ctx sdk.Context, headers []types.BitcoinHeader) sdk.Error {
+	first := headers[0].Height
+	last := headers[len(headers)-1].Height
+	for _, checkpoint := range k.getCheckpointsInRange(ctx, first, heightKey(last+1)) {
+		header := headers[checkpoint.Height-first]
+		if checkpoint.Digest != header.Hash {
+			return types.ErrCheckpointConflict(types.DefaultCodespace, header.Hash, header.Height, checkpoint.Digest)
+		}
+	}
+	return nil
+}
+
+// checkReorgAgainstCheckpoints errors if moving the best chain from knownBest
+// to newBest would conflict with a checkpoint. Every new best chain block
+// above the ancestor must match any checkpoint at its height, and no
+// checkpointed block on the current best chain may be orphaned
+func (k Keeper) checkReorgAgainstCheckpoints(ctx sdk.Context, ancestor, newBest types.BitcoinHeader) sdk.Error {
+	checkpoints := k.getCheckpointsInRange(ctx, ancestor.Height+1, nil)
+	if len(checkpoints) == 0 {
+		return nil
+	}
+
+	newChain := make(map[uint32]types.Hash256Digest)
+	current := newBest.Hash
+	for height := newBest.Height; height > ancestor.Height; height-- {
+		newChain[height] = current
+		current = k.getLink(ctx, current)
+	}
+
+	for _, checkpoint := range checkpoints {
+		if digest, ok := newChain[checkpoint.Height]; ok {
+			if digest != checkpoint.Digest {
+				return types.ErrCheckpointConflict(types.DefaultCodespace, digest, checkpoint.Height, checkpoint.Digest)
+			}
+			continue
+		}
+
+		// Above the new tip. The reorg may not drop a checkpointed block
+		bestDigest, err := k.GetDigestByHeight(ctx, checkpoint.Height)
+		if err == nil && bestDigest == checkpoint.Digest {
+			return types.ErrCheckpointConflict(types.DefaultCodespace, newBest.Hash, newBest.Height, checkpoint.Digest)
+		}
+	}
+	return nil
+}
+
+// RaiseGenesis moves the relay genesis up to a checkpointed best chain block.
+// The new genesis loses its link, so no chain may fork from below it, and the
+// headers below it are deleted as if pruned
+func (k Keeper) RaiseGenesis(ctx sdk.Context, height uint32) sdk.Error {
+	checkpoint, err := k.GetCheckpoint(ctx, height)
+	if err != nil {
+		return err
+	}
+	bestDigest, err := k.GetDigestByHeight(ctx, height)
+	if err != nil || bestDigest != checkpoint {
+		return types.ErrBadGenesisRaise(types.DefaultCodespace, height)
+	}
+
+	genesisDigest, err := k.GetRelayGenesis(ctx)
+	if err != nil {
+		return err
+	}
+	genesis, err := k.GetHeader(ctx, genesisDigest)
+	if err != nil {
+		return err
+	}
+	if height <= genesis.Height {
+		return types.ErrBadGenesisRaise(types.DefaultCodespace, height)
+	}
+
+	return k.moveGenesis(ctx, genesis, height, checkpoint)
+}
+
+// moveGenesis makes the best chain block at height the relay genesis, and
+// deletes the best chain headers below it. The first header of each retarget
+// period is kept, as IngestDifficultyChange reads it. Chains can no longer
+// fork from below the genesis, so forks that already do are deleted
+func (k Keeper) moveGenesis(ctx sdk.Context, genesis types.BitcoinHeader, height uint32, digestLE types.Hash256Digest) sdk.Error {
+	var pruned []types.HeightDigest
+	for h := genesis.Height; h < height; h++ {
+		digest, err := k.GetDigestByHeight(ctx, h)
+		if err != nil {
+			return err
+		}
+		pruned = append(pruned, types.HeightDigest{Height: h, Digest: digest})
+	}
+
+	// The last reorg LCA must stay reachable from the best chain
+	lcaDigest, err := k.GetLastReorgLCA(ctx)
+	if err != nil {
+		return err
+	}
+	lca, err := k.GetHeader(ctx, lcaDigest)
+	if err != nil {
+		return err
+	}
+	if lca.Height < height {
+		k.setLastReorgLCA(ctx, digestLE)
+	}
+
+	// Branch bases are found with the height index, so forks go first
+	bestDigest, err := k.GetBestKnownDigest(ctx)
+	if err != nil {
+		return err
+	}
+	k.pruneForksBelow(ctx, genesis, bestDigest, height)
+
+	interval := k.GetChainParams(ctx).RetargetInterval
+	for _, entry := range pruned {
+		k.deleteHeightDigest(ctx, entry.Height)
+		if entry.Height%interval == 0 {
+			// The parent of a kept epoch start is gone
+			k.deleteLink(ctx, entry.Digest)
+		} else {
+			k.deleteHeader(ctx, entry.Digest)
+		}
+		k.deleteFillsByDigest(ctx, entry.Digest)
+	}
+	k.deleteLink(ctx, digestLE)
+	k.setRelayGenesis(ctx, digestLE)
+	return nil
+}
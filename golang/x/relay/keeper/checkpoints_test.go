@@ -0,0 +1,173 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/summa-tx/relays/golang/x/relay/types"
+)
+
+func (s *KeeperSuite) TestCheckpointStore() {
+	tv := s.Fixtures.ChainTestCases.IsMostRecentCA
+
+	_, err := s.Keeper.GetCheckpoint(s.Context, tv.Genesis.Height)
+	s.Equal(sdk.CodeType(types.UnknownCheckpoint), err.Code())
+	err = s.Keeper.DeleteCheckpoint(s.Context, tv.Genesis.Height)
+	s.Equal(sdk.CodeType(types.UnknownCheckpoint), err.Code())
+
+	high := types.Checkpoint{Height: tv.Orphan.Height, Digest: tv.Orphan.Hash}
+	low := types.Checkpoint{Height: tv.Genesis.Height, Digest: tv.Genesis.Hash}
+	s.Keeper.SetCheckpoint(s.Context, high)
+	s.Keeper.SetCheckpoint(s.Context, low)
+
+	digest, err := s.Keeper.GetCheckpoint(s.Context, low.Height)
+	s.SDKNil(err)
+	s.Equal(low.Digest, digest)
+	s.Equal([]types.Checkpoint{low, high}, s.Keeper.GetAllCheckpoints(s.Context))
+
+	err = s.Keeper.DeleteCheckpoint(s.Context, low.Height)
+	s.SDKNil(err)
+	s.Equal([]types.Checkpoint{high}, s.Keeper.GetAllCheckpoints(s.Context))
+}
+
+func (s *KeeperSuite) TestIngestCheckpointConflict() {
+	tv := s.Fixtures.ChainTestCases.IsMostRecentCA
+	pre := tv.PreRetargetChain
+	post := tv.PostRetargetChain
+	var postWithOrphan []types.BitcoinHeader
+	postWithOrphan = append(postWithOrphan, post[:len(post)-2]...)
+	postWithOrphan = append(postWithOrphan, tv.Orphan)
+
+	err := s.Keeper.SetGenesisState(s.Context, tv.Genesis, tv.OldPeriodStart)
+	s.SDKNil(err)
+
+	// a checkpoint for a different block rejects the chain
+	s.Keeper.SetCheckpoint(s.Context, types.Checkpoint{Height: pre[2].Height, Digest: pre[3].Hash})
+	err = s.Keeper.IngestHeaderChain(s.Context, pre)
+	s.Equal(sdk.CodeType(types.CheckpointConflict), err.Code())
+
+	// a matching checkpoint does not
+	s.Keeper.SetCheckpoint(s.Context, types.Checkpoint{Height: pre[2].Height, Digest: pre[2].Hash})
+	err = s.Keeper.IngestHeaderChain(s.Context, pre)
+	s.SDKNil(err)
+
+	// forks are checked too
+	s.Keeper.SetCheckpoint(s.Context, types.Checkpoint{Height: tv.Orphan.Height, Digest: post[len(post)-2].Hash})
+	err = s.Keeper.IngestDifficultyChange(s.Context, tv.OldPeriodStart.Hash, post)
+	s.SDKNil(err)
+	err = s.Keeper.IngestDifficultyChange(s.Context, tv.OldPeriodStart.Hash, postWithOrphan)
+	s.Equal(sdk.CodeType(types.CheckpointConflict), err.Code())
+}
+
+func (s *KeeperSuite) TestMarkNewHeaviestCheckpointConflict() {
+	tv := s.Fixtures.ChainTestCases.IsMostRecentCA
+	pre := tv.PreRetargetChain
+	post := tv.PostRetargetChain
+	tip := post[len(post)-1]
+	var postWithOrphan []types.BitcoinHeader
+	postWithOrphan = append(postWithOrphan, post[:len(post)-2]...)
+	postWithOrphan = append(postWithOrphan, tv.Orphan)
+
+	err := s.Keeper.SetGenesisState(s.Context, tv.Genesis, tv.OldPeriodStart)
+	s.SDKNil(err)
+	err = s.Keeper.IngestHeaderChain(s.Context, pre)
+	s.SDKNil(err)
+	err = s.Keeper.IngestDifficultyChange(s.Context, tv.OldPeriodStart.Hash, post)
+	s.SDKNil(err)
+	err = s.Keeper.IngestDifficultyChange(s.Context, tv.OldPeriodStart.Hash, postWithOrphan)
+	s.SDKNil(err)
+	err = s.Keeper.MarkNewHeaviest(s.Context, tv.Genesis.Hash, tv.Genesis.Raw, tv.Orphan.Raw, 20)
+	s.SDKNil(err)
+
+	// the orphan is checkpointed after it was ingested
+	s.Keeper.SetCheckpoint(s.Context, types.Checkpoint{Height: tv.Orphan.Height, Digest: tv.Orphan.Hash})
+	err = s.Keeper.MarkNewHeaviest(s.Context, post[5].Hash, tv.Orphan.Raw, tip.Raw, 20)
+	s.Equal(sdk.CodeType(types.CheckpointConflict), err.Code())
+
+	// a checkpoint above the current best only constrains the new chain
+	err = s.Keeper.DeleteCheckpoint(s.Context, tv.Orphan.Height)
+	s.SDKNil(err)
+	s.Keeper.SetCheckpoint(s.Context, types.Checkpoint{Height: tip.Height, Digest: tip.Hash})
+	err = s.Keeper.MarkNewHeaviest(s.Context, post[5].Hash, tv.Orphan.Raw, tip.Raw, 20)
+	s.SDKNil(err)
+}
+
+func (s *KeeperSuite) TestCheckpointProposal() {
+	tv := s.Fixtures.ChainTestCases.IsMostRecentCA
+	pre := tv.PreRetargetChain
+	handler := NewProposalHandler(s.Keeper)
+
+	err := s.Keeper.SetGenesisState(s.Context, tv.Genesis, tv.OldPeriodStart)
+	s.SDKNil(err)
+	err = s.Keeper.IngestHeaderChain(s.Context, pre)
+	s.SDKNil(err)
+	err = s.Keeper.MarkNewHeaviest(s.Context, tv.Genesis.Hash, tv.Genesis.Raw, pre[len(pre)-1].Raw, 20)
+	s.SDKNil(err)
+
+	// checkpoints may not contradict the best chain
+	p := types.NewCheckpointProposal("title", "description", []types.Checkpoint{{Height: pre[1].Height, Digest: pre[2].Hash}}, nil, 0)
+	err = handler(s.Context, p)
+	s.Equal(sdk.CodeType(types.CheckpointConflict), err.Code())
+
+	// genesis can only be raised to a checkpoint
+	p = types.NewCheckpointProposal("title", "description", nil, nil, pre[2].Height)
+	err = handler(s.Context, p)
+	s.Equal(sdk.CodeType(types.UnknownCheckpoint), err.Code())
+
+	// add checkpoints and raise genesis in one proposal
+	checkpoint := types.Checkpoint{Height: pre[2].Height, Digest: pre[2].Hash}
+	p = types.NewCheckpointProposal("title", "description", []types.Checkpoint{checkpoint}, nil, pre[2].Height)
+	err = handler(s.Context, p)
+	s.SDKNil(err)
+
+	genesis, err := s.Keeper.GetRelayGenesis(s.Context)
+	s.SDKNil(err)
+	s.Equal(pre[2].Hash, genesis)
+	lca, err := s.Keeper.GetLastReorgLCA(s.Context)
+	s.SDKNil(err)
+	s.Equal(pre[2].Hash, lca)
+	s.False(s.Keeper.hasLink(s.Context, pre[2].Hash))
+	_, err = s.Keeper.GetDigestByHeight(s.Context, pre[1].Height)
+	s.Equal(sdk.CodeType(types.UnknownHeight), err.Code())
+	digest, err := s.Keeper.GetDigestByHeight(s.Context, pre[2].Height)
+	s.SDKNil(err)
+	s.Equal(pre[2].Hash, digest)
+
+	// genesis can not be lowered
+	s.Keeper.SetCheckpoint(s.Context, types.Checkpoint{Height: pre[1].Height, Digest: pre[1].Hash})
+	err = s.Keeper.RaiseGenesis(s.Context, pre[1].Height)
+	s.Equal(sdk.CodeType(types.BadGenesisRaise), err.Code())
+
+	// checkpoints can be removed
+	p = types.NewCheckpointProposal("title", "description", nil, []uint32{pre[1].Height}, 0)
+	err = handler(s.Context, p)
+	s.SDKNil(err)
+	_, err = s.Keeper.GetCheckpoint(s.Context, pre[1].Height)
+	s.Equal(sdk.CodeType(types.UnknownCheckpoint), err.Code())
+}
+
+func (s *KeeperSuite) TestRaiseGenesisDeletesHistory() {
+	genesis, main := s.initPruneTest(10)
+	fork := mineChain(main[0], 2, 601)
+	s.SDKNil(s.Keeper.IngestHeaderChain(s.Context, fork))
+
+	s.Keeper.SetCheckpoint(s.Context, types.Checkpoint{Height: main[5].Height, Digest: main[5].Hash})
+	s.SDKNil(s.Keeper.RaiseGenesis(s.Context, main[5].Height))
+
+	// headers below the new genesis are deleted, except epoch starts
+	s.True(s.Keeper.HasHeader(s.Context, genesis.Hash))
+	s.True(s.Keeper.HasHeader(s.Context, main[3].Hash))
+	s.False(s.Keeper.hasLink(s.Context, main[3].Hash))
+	for _, header := range []types.BitcoinHeader{main[0], main[1], main[2], main[4]} {
+		s.False(s.Keeper.HasHeader(s.Context, header.Hash))
+	}
+
+	// as are forks from below it, so only the best tip is left
+	for _, header := range fork {
+		s.False(s.Keeper.HasHeader(s.Context, header.Hash))
+	}
+	tips, err := s.Keeper.GetChainTips(s.Context)
+	s.SDKNil(err)
+	s.Equal(1, len(tips))
+	s.Equal(main[9].Hash, tips[0].Digest)
+
+	s.checkExportImport()
+}
@@ -0,0 +1,159 @@
+package keeper
+
+import (
+	"encoding/binary"
+
+	"github.com/summa-tx/relays/golang/x/relay/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func (k Keeper) emitRequestExpired(ctx sdk.Context, id types.RequestID) {
+	ctx.EventManager().EmitEvent(types.NewRequestExpiredEvent(id))
+}
+
+// getRequestExpiryStore returns the expiry index of active requests by
+// Cosmos block height. Keys are the BE expiry height followed by the request
+// ID, and values are the request ID
+func (k Keeper) getRequestExpiryStore(ctx sdk.Context) sdk.KVStore {
+	return k.getPrefixStore(ctx, types.RequestExpiryStorePrefix)
+}
+
+// getRequestBTCExpiryStore returns the expiry index of active requests by
+// Bitcoin height. Keys are the BE expiry height followed by the request ID,
+// and values are the request ID
+func (k Keeper) getRequestBTCExpiryStore(ctx sdk.Context) sdk.KVStore {
+	return k.getPrefixStore(ctx, types.RequestBTCExpiryStorePrefix)
+}
+
+func requestExpiryKey(expiry int64, id types.RequestID) []byte {
+	buf := make([]byte, 8, 16)
+	binary.BigEndian.PutUint64(buf, uint64(expiry))
+	return append(buf, id[:]...)
+}
+
+func requestBTCExpiryKey(expiry uint32, id types.RequestID) []byte {
+	return append(heightKey(expiry), id[:]...)
+}
+
+// indexRequestExpiry keeps a request in the expiry indexes while it is
+// active, and removes it once it is closed. It is called whenever a request
+// is stored
+func (k Keeper) indexRequestExpiry(ctx sdk.Context, id types.RequestID, request types.ProofRequest) {
+	if request.ExpiryHeight != 0 {
+		store := k.getRequestExpiryStore(ctx)
+		key := requestExpiryKey(request.ExpiryHeight, id)
+		if request.ActiveState {
+			store.Set(key, id[:])
+		} else {
+			store.Delete(key)
+		}
+	}
+	if request.BTCExpiryHeight != 0 {
+		store := k.getRequestBTCExpiryStore(ctx)
+		key := requestBTCExpiryKey(request.BTCExpiryHeight, id)
+		if request.ActiveState {
+			store.Set(key, id[:])
+		} else {
+			store.Delete(key)
+		}
+	}
+}
+
+// getBestHeight returns the height of the best known digest
+func (k Keeper) getBestHeight(ctx sdk.Context) (uint32, sdk.Error) {
+	bestKnown, err := k.GetBestKnownDigest(ctx)
+	if err != nil {
+		return 0, err
+	}
+	header, err := k.GetHeader(ctx, bestKnown)
+	if err != nil {
+		return 0, err
+	}
+	return header.Height, nil
+}
+
+// validateRequestExpiry checks that a new request's expiry heights have not
+// already passed
+func (k Keeper) validateRequestExpiry(ctx sdk.Context, expiryHeight int64, btcExpiryHeight uint32) sdk.Error {
+	if expiryHeight != 0 && expiryHeight < ctx.BlockHeight() {
+		return types.ErrExpiryPassed(types.DefaultCodespace, "Cosmos", expiryHeight, ctx.BlockHeight())
+	}
+	if btcExpiryHeight != 0 {
+		bestHeight, err := k.getBestHeight(ctx)
+		if err != nil {
+			return err
+		}
+		if btcExpiryHeight < bestHeight {
+			return types.ErrExpiryPassed(types.DefaultCodespace, "Bitcoin", int64(btcExpiryHeight), int64(bestHeight))
+		}
+	}
+	return nil
+}
+
+// isExpired checks whether a request is past its expiry heights, or closes
+// at the end of the current block. It matches the requests ExpireRequests
+// closes, so a request is never reopened only to expire in the same block
+func (k Keeper) isExpired(ctx sdk.Context, request types.ProofRequest) bool {
+	if request.ExpiryHeight != 0 && request.ExpiryHeight <= ctx.BlockHeight() {
+		return true
+	}
+	if request.BTCExpiryHeight != 0 {
+		bestHeight, err := k.getBestHeight(ctx)
+		if err == nil && request.BTCExpiryHeight < bestHeight {
+			return true
+		}
+	}
+	return false
+}
+
+// getExpiredIDs returns the IDs in an expiry index with keys before end
+func getExpiredIDs(store sdk.KVStore, end []byte) []types.RequestID {
+	iterator := store.Iterator(nil, end)
+	defer iterator.Close()
+
+	ids := []types.RequestID{}
+	for ; iterator.Valid(); iterator.Next() {
+		// Can only fail if data store is corrupt
+		id, _ := types.NewRequestID(iterator.Value())
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// ExpireRequests closes the active requests that expire at or before the
+// current block, and those whose Bitcoin expiry height is below the best
+// known digest, and refunds their escrow. It is run from the module's EndBlock
+func (k Keeper) ExpireRequests(ctx sdk.Context) sdk.Error {
+	end := make([]byte, 8)
+	binary.BigEndian.PutUint64(end, uint64(ctx.BlockHeight()+1))
+	expired := getExpiredIDs(k.getRequestExpiryStore(ctx), end)
+
+	// The relay may not be initialized yet
+	bestHeight, err := k.getBestHeight(ctx)
+	if err == nil {
+		expired = append(expired, getExpiredIDs(k.getRequestBTCExpiryStore(ctx), heightKey(bestHeight))...)
+	}
+
+	for _, id := range expired {
+		request, err := k.getRequest(ctx, id)
+		if err != nil {
+			return err
+		}
+		// A request with both expiry heights may be in both lists
+		if !request.ActiveState {
+			continue
+		}
+		err = k.refundEscrow(ctx, &request)
+		if err != nil {
+			return err
+		}
+		request.ActiveState = false
+		err = k.storeRequest(ctx, id, request)
+		if err != nil {
+			return err
+		}
+		k.emitRequestExpired(ctx, id)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/summa-tx/relays/golang/x/relay/types"
+)
+
+func (s *KeeperSuite) TestValidateRequestExpiry() {
+	s.Context = s.Context.WithBlockHeight(10)
+
+	// no expiry is always valid
+	s.SDKNil(s.Keeper.validateRequestExpiry(s.Context, 0, 0))
+
+	// errors if the Cosmos height has passed
+	err := s.Keeper.validateRequestExpiry(s.Context, 9, 0)
+	s.Equal(sdk.CodeType(types.ExpiryPassed), err.Code())
+	s.SDKNil(s.Keeper.validateRequestExpiry(s.Context, 10, 0))
+
+	// errors if the relay has no best known digest
+	err = s.Keeper.validateRequestExpiry(s.Context, 0, 1)
+	s.Equal(sdk.CodeType(types.BadHash256Digest), err.Code())
+
+	// errors if the Bitcoin height has passed
+	_, main := s.initPruneTest(4)
+	best := main[3].Height
+	err = s.Keeper.validateRequestExpiry(s.Context, 0, best-1)
+	s.Equal(sdk.CodeType(types.ExpiryPassed), err.Code())
+	s.SDKNil(s.Keeper.validateRequestExpiry(s.Context, 0, best))
+}
+
+func (s *KeeperSuite) TestIsExpired() {
+	_, main := s.initPruneTest(4)
+	best := main[3].Height
+	s.Context = s.Context.WithBlockHeight(10)
+
+	// agrees with ExpireRequests, which closes requests at the end of the
+	// Cosmos block at their expiry height
+	s.False(s.Keeper.isExpired(s.Context, types.ProofRequest{}))
+	s.False(s.Keeper.isExpired(s.Context, types.ProofRequest{ExpiryHeight: 11}))
+	s.True(s.Keeper.isExpired(s.Context, types.ProofRequest{ExpiryHeight: 10}))
+	s.True(s.Keeper.isExpired(s.Context, types.ProofRequest{ExpiryHeight: 9}))
+
+	// and once the best chain is above their Bitcoin expiry height
+	s.False(s.Keeper.isExpired(s.Context, types.ProofRequest{BTCExpiryHeight: best}))
+	s.True(s.Keeper.isExpired(s.Context, types.ProofRequest{BTCExpiryHeight: best - 1}))
+
+	// requests isExpired reports are the ones the sweep closes
+	s.SDKNil(s.Keeper.setRequest(s.Context, nil, []byte{}, []byte{}, 0, 0, types.Local, nil, types.RequestOptions{ExpiryHeight: 10}))
+	s.SDKNil(s.Keeper.setRequest(s.Context, nil, []byte{}, []byte{}, 0, 0, types.Local, nil, types.RequestOptions{ExpiryHeight: 11}))
+	s.SDKNil(s.Keeper.setRequest(s.Context, nil, []byte{}, []byte{}, 0, 0, types.Local, nil, types.RequestOptions{BTCExpiryHeight: best}))
+	s.SDKNil(s.Keeper.setRequest(s.Context, nil, []byte{}, []byte{}, 0, 0, types.Local, nil, types.RequestOptions{BTCExpiryHeight: best - 1}))
+	requests := []types.ProofRequest{}
+	for i := byte(0); i < 4; i++ {
+		request, err := s.Keeper.getRequest(s.Context, types.RequestID{0, 0, 0, 0, 0, 0, 0, i})
+		s.SDKNil(err)
+		requests = append(requests, request)
+	}
+	s.SDKNil(s.Keeper.ExpireRequests(s.Context))
+	for i, request := range requests {
+		closed, err := s.Keeper.getRequest(s.Context, types.RequestID{0, 0, 0, 0, 0, 0, 0, byte(i)})
+		s.SDKNil(err)
+		s.Equal(s.Keeper.isExpired(s.Context, request), !closed.ActiveState)
+	}
+}
+
+func (s *KeeperSuite) TestExpireRequests() {
+	// does nothing before genesis is set
+	s.SDKNil(s.Keeper.ExpireRequests(s.Context))
+
+	_, main := s.initPruneTest(4)
+	best := main[3].Height
+	s.Context = s.Context.WithBlockHeight(5)
+	s.SDKNil(s.Keeper.setRequest(s.Context, nil, []byte{}, []byte{}, 0, 0, types.Local, nil, types.RequestOptions{}))
+	s.SDKNil(s.Keeper.setRequest(s.Context, nil, []byte{}, []byte{}, 0, 0, types.Local, nil, types.RequestOptions{ExpiryHeight: 10}))
+	s.SDKNil(s.Keeper.setRequest(s.Context, nil, []byte{}, []byte{}, 0, 0, types.Local, nil, types.RequestOptions{BTCExpiryHeight: best}))
+	s.SDKNil(s.Keeper.setRequest(s.Context, nil, []byte{}, []byte{}, 0, 0, types.Local, nil, types.RequestOptions{ExpiryHeight: 20, BTCExpiryHeight: best - 1}))
+	none := types.RequestID{0, 0, 0, 0, 0, 0, 0, 0}
+	cosmos := types.RequestID{0, 0, 0, 0, 0, 0, 0, 1}
+	bitcoin := types.RequestID{0, 0, 0, 0, 0, 0, 0, 2}
+	both := types.RequestID{0, 0, 0, 0, 0, 0, 0, 3}
+
+	active := func(id types.RequestID) bool {
+		request, err := s.Keeper.getRequest(s.Context, id)
+		s.SDKNil(err)
+		return request.ActiveState
+	}
+	expired := func() []string {
+		var ids []string
+		for _, event := range s.Context.EventManager().Events() {
+			if event.Type == types.EventTypeRequestExpired {
+				ids = append(ids, string(event.Attributes[0].Value))
+			}
+		}
+		return ids
+	}
+	str := func(id types.RequestID) string { return fmt.Sprintf("%d", id) }
+
+	// closes requests once the best Bitcoin block is above the expiry height
+	s.SDKNil(s.Keeper.ExpireRequests(s.Context))
+	s.True(active(none))
+	s.True(active(cosmos))
+	s.True(active(bitcoin))
+	s.False(active(both))
+	s.Equal([]string{str(both)}, expired())
+
+	// closes requests at the end of the Cosmos expiry block
+	s.Context = s.Context.WithBlockHeight(9)
+	s.SDKNil(s.Keeper.ExpireRequests(s.Context))
+	s.True(active(cosmos))
+	s.Context = s.Context.WithBlockHeight(10)
+	s.SDKNil(s.Keeper.ExpireRequests(s.Context))
+	s.False(active(cosmos))
+	s.Equal([]string{str(both), str(cosmos)}, expired())
+
+	s.SDKNil(s.Keeper.IngestHeaderChain(s.Context, mineChain(main[3], 1, 600)))
+	s.SDKNil(s.Keeper.ExpireRequests(s.Context))
+	s.False(active(bitcoin))
+	s.True(active(none))
+	s.Equal([]string{str(both), str(cosmos), str(bitcoin)}, expired())
+
+	// closed requests leave the expiry indexes
+	s.Equal(0, len(getExpiredIDs(s.Keeper.getRequestExpiryStore(s.Context), nil)))
+	s.Equal(0, len(getExpiredIDs(s.Keeper.getRequestBTCExpiryStore(s.Context), nil)))
+	s.Context = s.Context.WithBlockHeight(30)
+	s.SDKNil(s.Keeper.ExpireRequests(s.Context))
+	s.Equal(3, len(expired()))
+}
+
+func (s *KeeperSuite) TestReopenOrphanedFillsExpiry() {
+	_, main := s.initPruneTest(4)
+	s.Context = s.Context.WithBlockHeight(5)
+	s.SDKNil(s.Keeper.setRequest(s.Context, nil, []byte{}, []byte{}, 0, 0, types.Local, nil, types.RequestOptions{BTCExpiryHeight: main[2].Height}))
+	s.SDKNil(s.Keeper.setRequest(s.Context, nil, []byte{}, []byte{}, 0, 0, types.Local, nil, types.RequestOptions{BTCExpiryHeight: main[3].Height + 1}))
+	s.SDKNil(s.Keeper.setRequest(s.Context, nil, []byte{}, []byte{}, 0, 0, types.Local, nil, types.RequestOptions{ExpiryHeight: s.Context.BlockHeight()}))
+	expiring := types.RequestID{0, 0, 0, 0, 0, 0, 0, 0}
+	open := types.RequestID{0, 0, 0, 0, 0, 0, 0, 1}
+	closing := types.RequestID{0, 0, 0, 0, 0, 0, 0, 2}
+	s.fillAt(main[2], expiring)
+	s.fillAt(main[2], open)
+	s.fillAt(main[2], closing)
+
+	// a heavier fork from main[1] passes the first request's expiry
+	fork := mineChain(main[1], 3, 601)
+	s.SDKNil(s.Keeper.IngestHeaderChain(s.Context, fork))
+
+	// expired requests stay closed, but their fills are undone
+	request, err := s.Keeper.getRequest(s.Context, expiring)
+	s.SDKNil(err)
+	s.False(request.ActiveState)
+	s.Equal(uint32(0), request.NumFills)
+	request, err = s.Keeper.getRequest(s.Context, open)
+	s.SDKNil(err)
+	s.True(request.ActiveState)
+
+	// requests that expire at the end of this block are not reopened
+	request, err = s.Keeper.getRequest(s.Context, closing)
+	s.SDKNil(err)
+	s.False(request.ActiveState)
+	s.Equal(uint32(0), request.NumFills)
+}
@@ -0,0 +1,263 @@
+package keeper
+
+import (
+	"github.com/summa-tx/bitcoin-spv/golang/btcspv"
+	"github.com/summa-tx/relays/golang/x/relay/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func (k Keeper) emitRequestReopened(ctx sdk.Context, fill types.Fill) {
+	ctx.EventManager().EmitEvent(types.NewRequestReopenedEvent(fill))
+}
+
+// getFillStore returns the store of request fills. Keys are the confirming
+// digest, the request ID and the filling TXID, so that the fills confirmed by
+// a block can be found with a prefix scan. The value is the filling TXID
+func (k Keeper) getFillStore(ctx sdk.Context) sdk.KVStore {
+	return k.getPrefixStore(ctx, types.FillStorePrefix)
+}
+
+func fillKey(confirming types.Hash256Digest, id types.RequestID, txid types.Hash256Digest) []byte {
+	key := append(confirming[:], id[:]...)
+	return append(key, txid[:]...)
+}
+
+// decodeFill parses an entry of the fill store
+func decodeFill(key, value []byte) types.Fill {
+	// Can only fail if data store is corrupt
+	confirming, _ := btcspv.NewHash256Digest(key[:32])
+	txid, _ := btcspv.NewHash256Digest(value)
+	id, _ := types.NewRequestID(key[32:40])
+	return types.Fill{ID: id, TxID: txid, ConfirmingDigest: confirming}
+}
+
+// setFill records the block that confirmed a fill
+func (k Keeper) setFill(ctx sdk.Context, fill types.Fill) {
+	store := k.getFillStore(ctx)
+	store.Set(fillKey(fill.ConfirmingDigest, fill.ID, fill.TxID), fill.TxID[:])
+}
+
+// deleteFill removes a fill record
+func (k Keeper) deleteFill(ctx sdk.Context, fill types.Fill) {
+	store := k.getFillStore(ctx)
+	store.Delete(fillKey(fill.ConfirmingDigest, fill.ID, fill.TxID))
+}
+
+// getRequestFillStore returns the store of each request's fills. Keys are
+// the request ID followed by the filling TXID. The value is the confirming
+// digest. Unlike the fill store, these records are kept when the confirming
+// block is pruned
+func (k Keeper) getRequestFillStore(ctx sdk.Context) sdk.KVStore {
+	return k.getPrefixStore(ctx, types.RequestFillStorePrefix)
+}
+
+// decodeRequestFill parses an entry of the request fill store
+func decodeRequestFill(key, value []byte) types.Fill {
+	// Can only fail if data store is corrupt
+	id, _ := types.NewRequestID(key[:8])
+	txid, _ := btcspv.NewHash256Digest(key[8:])
+	confirming, _ := btcspv.NewHash256Digest(value)
+	return types.Fill{ID: id, TxID: txid, ConfirmingDigest: confirming}
+}
+
+// getProvenTxStore returns the index of the requests filled by each
+// transaction. Keys are the TXID followed by the request ID. The value is
+// the confirming digest. It mirrors the request fill store
+func (k Keeper) getProvenTxStore(ctx sdk.Context) sdk.KVStore {
+	return k.getPrefixStore(ctx, types.ProvenTxStorePrefix)
+}
+
+// setRequestFill adds a fill to its request's history, and to the index of
+// proven transactions
+func (k Keeper) setRequestFill(ctx sdk.Context, fill types.Fill) {
+	store := k.getRequestFillStore(ctx)
+	store.Set(append(fill.ID[:], fill.TxID[:]...), fill.ConfirmingDigest[:])
+	provenStore := k.getProvenTxStore(ctx)
+	provenStore.Set(append(fill.TxID[:], fill.ID[:]...), fill.ConfirmingDigest[:])
+}
+
+// deleteRequestFill removes a fill from its request's history, and from the
+// index of proven transactions
+func (k Keeper) deleteRequestFill(ctx sdk.Context, fill types.Fill) {
+	store := k.getRequestFillStore(ctx)
+	store.Delete(append(fill.ID[:], fill.TxID[:]...))
+	provenStore := k.getProvenTxStore(ctx)
+	provenStore.Delete(append(fill.TxID[:], fill.ID[:]...))
+}
+
+// hasRequestFill checks whether a transaction has already filled a request
+func (k Keeper) hasRequestFill(ctx sdk.Context, id types.RequestID, txid types.Hash256Digest) bool {
+	store := k.getRequestFillStore(ctx)
+	return store.Has(append(id[:], txid[:]...))
+}
+
+// GetProvenTx returns the requests a transaction has filled, and the header
+// that confirmed it. If the confirming header has been pruned, the result
+// is marked as pruned and only has the confirming digest
+func (k Keeper) GetProvenTx(ctx sdk.Context, txid types.Hash256Digest) (types.ProvenTx, sdk.Error) {
+	store := k.getProvenTxStore(ctx)
+	iterator := sdk.KVStorePrefixIterator(store, txid[:])
+	defer iterator.Close()
+
+	proven := types.ProvenTx{TxID: txid, Requests: []types.IdentifiedRequest{}}
+	var confirming types.Hash256Digest
+	for ; iterator.Valid(); iterator.Next() {
+		// Can only fail if data store is corrupt
+		id, _ := types.NewRequestID(iterator.Key()[32:])
+		request, err := k.getRequest(ctx, id)
+		if err != nil {
+			return types.ProvenTx{}, err
+		}
+		proven.Requests = append(proven.Requests, types.IdentifiedRequest{ID: id, Request: request})
+		// Fills from orphaned blocks are dropped, so a transaction's fills
+		// share one confirming block
+		confirming, _ = btcspv.NewHash256Digest(iterator.Value())
+	}
+	if len(proven.Requests) == 0 {
+		return types.ProvenTx{}, types.ErrUnknownProvenTx(types.DefaultCodespace, txid)
+	}
+
+	proven.ConfirmingDigest = confirming
+	if !k.HasHeader(ctx, confirming) {
+		proven.Pruned = true
+		return proven, nil
+	}
+	header, err := k.GetHeader(ctx, confirming)
+	if err != nil {
+		return types.ProvenTx{}, err
+	}
+	proven.ConfirmingHeader = header
+	return proven, nil
+}
+
+// getFillsByRequest returns the fills of a request, ordered by TXID
+func (k Keeper) getFillsByRequest(ctx sdk.Context, id types.RequestID) []types.Fill {
+	store := k.getRequestFillStore(ctx)
+	iterator := sdk.KVStorePrefixIterator(store, id[:])
+	defer iterator.Close()
+
+	fills := []types.Fill{}
+	for ; iterator.Valid(); iterator.Next() {
+		fills = append(fills, decodeRequestFill(iterator.Key(), iterator.Value()))
+	}
+	return fills
+}
+
+// getAllRequestFills returns the fill history of every request, ordered by
+// request ID
+func (k Keeper) getAllRequestFills(ctx sdk.Context) []types.Fill {
+	store := k.getRequestFillStore(ctx)
+	iterator := sdk.KVStorePrefixIterator(store, nil)
+	defer iterator.Close()
+
+	fills := []types.Fill{}
+	for ; iterator.Valid(); iterator.Next() {
+		fills = append(fills, decodeRequestFill(iterator.Key(), iterator.Value()))
+	}
+	return fills
+}
+
+// getFillsByDigest returns the fills confirmed by a block, ordered by request
+// ID
+func (k Keeper) getFillsByDigest(ctx sdk.Context, confirming types.Hash256Digest) []types.Fill {
+	store := k.getFillStore(ctx)
+	iterator := sdk.KVStorePrefixIterator(store, confirming[:])
+	defer iterator.Close()
+
+	fills := []types.Fill{}
+	for ; iterator.Valid(); iterator.Next() {
+		fills = append(fills, decodeFill(iterator.Key(), iterator.Value()))
+	}
+	return fills
+}
+
+// getAllFills returns every fill record, ordered by confirming digest
+func (k Keeper) getAllFills(ctx sdk.Context) []types.Fill {
+	store := k.getFillStore(ctx)
+	iterator := sdk.KVStorePrefixIterator(store, nil)
+	defer iterator.Close()
+
+	fills := []types.Fill{}
+	for ; iterator.Valid(); iterator.Next() {
+		fills = append(fills, decodeFill(iterator.Key(), iterator.Value()))
+	}
+	return fills
+}
+
+// reachedFillLimit checks whether a request has been filled as many times as
+// it allows. Requests stored before fill limits were introduced have a
+// MaxFills of 0, and may be filled any number of times, as they were then
+func reachedFillLimit(request types.ProofRequest) bool {
+	return request.MaxFills != 0 && request.NumFills >= request.MaxFills
+}
+
+// fillRequests counts a fill against each request filled by a valid proof,
+// and records the block that confirmed it. The prover is paid each request's
+// bounty. Requests are closed when they reach their fill limit
+func (k Keeper) fillRequests(ctx sdk.Context, prover sdk.AccAddress, filledRequests types.FilledRequests) sdk.Error {
+	proof := filledRequests.Proof
+	for _, filled := range filledRequests.Filled {
+		request, err := k.getRequest(ctx, filled.ID)
+		if err != nil {
+			return err
+		}
+		err = k.payBounty(ctx, prover, filled.ID, &request)
+		if err != nil {
+			return err
+		}
+		request.NumFills++
+		if reachedFillLimit(request) {
+			request.ActiveState = false
+		}
+		err = k.storeRequest(ctx, filled.ID, request)
+		if err != nil {
+			return err
+		}
+
+		fill := types.Fill{
+			ID:               filled.ID,
+			TxID:             proof.TxID,
+			ConfirmingDigest: proof.ConfirmingHeader.Hash,
+		}
+		k.setFill(ctx, fill)
+		k.setRequestFill(ctx, fill)
+	}
+	return nil
+}
+
+// reopenOrphanedFills undoes the fills confirmed by blocks that have left the
+// best chain, and drops their fill records. Requests that were closed by
+// reaching their fill limit are reactivated, unless they have expired or
+// their escrow can no longer pay the bounty. Cancelled requests stay closed
+func (k Keeper) reopenOrphanedFills(ctx sdk.Context, orphaned []types.Hash256Digest) sdk.Error {
+	for _, digest := range orphaned {
+		for _, fill := range k.getFillsByDigest(ctx, digest) {
+			request, err := k.getRequest(ctx, fill.ID)
+			if err != nil {
+				return err
+			}
+			if !request.ActiveState && reachedFillLimit(request) && !k.isExpired(ctx, request) && canPayBounty(request) {
+				request.ActiveState = true
+			}
+			request.NumFills--
+			err = k.storeRequest(ctx, fill.ID, request)
+			if err != nil {
+				return err
+			}
+
+			k.deleteFill(ctx, fill)
+			k.deleteRequestFill(ctx, fill)
+			k.emitRequestReopened(ctx, fill)
+		}
+	}
+	return nil
+}
+
+// deleteFillsByDigest drops the fill records of a block. Pruned blocks can
+// no longer be reorged out, so their fills are final
+func (k Keeper) deleteFillsByDigest(ctx sdk.Context, confirming types.Hash256Digest) {
+	for _, fill := range k.getFillsByDigest(ctx, confirming) {
+		k.deleteFill(ctx, fill)
+	}
+}
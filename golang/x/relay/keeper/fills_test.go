@@ -0,0 +1,218 @@
+package keeper
+
+import (
+	"encoding/hex"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/summa-tx/relays/golang/x/relay/types"
+)
+
+// fillAt fills a request with a proof confirmed by header. The header's
+// digest stands in for the TXID, so that each block fills with a different one
+func (s *KeeperSuite) fillAt(header types.BitcoinHeader, id types.RequestID) {
+	filled := types.FilledRequests{
+		Proof:  types.SPVProof{TxID: header.Hash, ConfirmingHeader: header},
+		Filled: []types.FilledRequestInfo{{ID: id}},
+	}
+	s.SDKNil(s.Keeper.fillRequests(s.Context, nil, filled))
+}
+
+func (s *KeeperSuite) TestFillRequests() {
+	header := s.Fixtures.ValidatorTestCases.ValidateProof[0].Proof.ConfirmingHeader
+	id := types.RequestID{}
+
+	// errors if the request is unknown
+	filled := types.FilledRequests{
+		Proof:  types.SPVProof{ConfirmingHeader: header},
+		Filled: []types.FilledRequestInfo{{ID: id}},
+	}
+	err := s.Keeper.fillRequests(s.Context, nil, filled)
+	s.Equal(sdk.CodeType(types.UnknownRequest), err.Code())
+
+	// closes the request and records the confirming block
+	s.SDKNil(s.Keeper.setRequest(s.Context, nil, []byte{}, []byte{}, 0, 0, types.Local, nil, types.RequestOptions{}))
+	s.fillAt(header, id)
+	request, err := s.Keeper.getRequest(s.Context, id)
+	s.SDKNil(err)
+	s.False(request.ActiveState)
+
+	s.Equal(uint32(1), request.NumFills)
+
+	fill := types.Fill{ID: id, TxID: header.Hash, ConfirmingDigest: header.Hash}
+	s.Equal([]types.Fill{fill}, s.Keeper.getFillsByDigest(s.Context, header.Hash))
+	s.Equal([]types.Fill{fill}, s.Keeper.getAllFills(s.Context))
+	s.Equal([]types.Fill{fill}, s.Keeper.getFillsByRequest(s.Context, id))
+
+	// pruning the confirming block keeps the request's history
+	s.Keeper.deleteFillsByDigest(s.Context, header.Hash)
+	s.Equal([]types.Fill{}, s.Keeper.getAllFills(s.Context))
+	s.Equal([]types.Fill{fill}, s.Keeper.getAllRequestFills(s.Context))
+}
+
+func (s *KeeperSuite) TestFillLimits() {
+	header := s.Fixtures.ValidatorTestCases.ValidateProof[0].Proof.ConfirmingHeader
+	id := types.RequestID{}
+	s.SDKNil(s.Keeper.setRequest(s.Context, nil, []byte{}, []byte{}, 0, 0, types.Local, nil, types.RequestOptions{MaxFills: 3}))
+
+	// stays open until the limit is reached
+	for i := uint32(1); i <= 3; i++ {
+		filled := types.FilledRequests{
+			Proof:  types.SPVProof{TxID: types.Hash256Digest{byte(i)}, ConfirmingHeader: header},
+			Filled: []types.FilledRequestInfo{{ID: id}},
+		}
+		s.SDKNil(s.Keeper.fillRequests(s.Context, nil, filled))
+		request, err := s.Keeper.getRequest(s.Context, id)
+		s.SDKNil(err)
+		s.Equal(i, request.NumFills)
+		s.Equal(i < 3, request.ActiveState)
+	}
+
+	// every fill is recorded, even in the same block
+	s.Equal(3, len(s.Keeper.getFillsByDigest(s.Context, header.Hash)))
+	fills := s.Keeper.getFillsByRequest(s.Context, id)
+	s.Equal(3, len(fills))
+	for i, fill := range fills {
+		s.Equal(types.Hash256Digest{byte(i + 1)}, fill.TxID)
+		s.Equal(header.Hash, fill.ConfirmingDigest)
+	}
+
+	// requests stored before fill limits have none
+	legacy := types.RequestID{0, 0, 0, 0, 0, 0, 0, 1}
+	s.SDKNil(s.Keeper.setRequest(s.Context, nil, []byte{}, []byte{}, 0, 0, types.Local, nil, types.RequestOptions{}))
+	request, err := s.Keeper.getRequest(s.Context, legacy)
+	s.SDKNil(err)
+	request.MaxFills = 0
+	s.SDKNil(s.Keeper.storeRequest(s.Context, legacy, request))
+	for i := uint32(1); i <= 3; i++ {
+		filled := types.FilledRequests{
+			Proof:  types.SPVProof{TxID: types.Hash256Digest{byte(i)}, ConfirmingHeader: header},
+			Filled: []types.FilledRequestInfo{{ID: legacy}},
+		}
+		s.SDKNil(s.Keeper.fillRequests(s.Context, nil, filled))
+	}
+	request, err = s.Keeper.getRequest(s.Context, legacy)
+	s.SDKNil(err)
+	s.Equal(uint32(3), request.NumFills)
+	s.True(request.ActiveState)
+}
+
+func (s *KeeperSuite) TestReopenOrphanedFills() {
+	_, main := s.initPruneTest(4)
+	for i := 0; i < 3; i++ {
+		s.SDKNil(s.Keeper.setRequest(s.Context, nil, []byte{}, []byte{}, 0, 0, types.Local, nil, types.RequestOptions{}))
+	}
+	creator := getAccAddress()
+	s.SDKNil(s.Keeper.setRequest(s.Context, creator, []byte{}, []byte{}, 0, 0, types.Local, nil, types.RequestOptions{MaxFills: 3}))
+	s.SDKNil(s.Keeper.setRequest(s.Context, nil, []byte{}, []byte{}, 0, 0, types.Local, nil, types.RequestOptions{MaxFills: 2}))
+	first := types.RequestID{0, 0, 0, 0, 0, 0, 0, 0}
+	second := types.RequestID{0, 0, 0, 0, 0, 0, 0, 1}
+	third := types.RequestID{0, 0, 0, 0, 0, 0, 0, 2}
+	cancelled := types.RequestID{0, 0, 0, 0, 0, 0, 0, 3}
+	partial := types.RequestID{0, 0, 0, 0, 0, 0, 0, 4}
+	s.fillAt(main[0], first)
+	s.fillAt(main[2], second)
+	s.fillAt(main[3], third)
+	s.fillAt(main[0], cancelled)
+	s.fillAt(main[2], cancelled)
+	s.SDKNil(s.Keeper.CancelRequest(s.Context, creator, cancelled))
+	s.fillAt(main[3], partial)
+
+	// a heavier fork from main[1] orphans main[2] and main[3]
+	fork := mineChain(main[1], 3, 601)
+	err := s.Keeper.IngestHeaderChain(s.Context, fork)
+	s.SDKNil(err)
+	best, err := s.Keeper.GetBestKnownDigest(s.Context)
+	s.SDKNil(err)
+	s.Equal(fork[2].Hash, best)
+
+	for _, id := range []types.RequestID{second, third} {
+		request, err := s.Keeper.getRequest(s.Context, id)
+		s.SDKNil(err)
+		s.True(request.ActiveState)
+	}
+	request, err := s.Keeper.getRequest(s.Context, first)
+	s.SDKNil(err)
+	s.False(request.ActiveState)
+
+	// orphaned fills are no longer counted, but cancelled requests stay closed
+	request, err = s.Keeper.getRequest(s.Context, cancelled)
+	s.SDKNil(err)
+	s.False(request.ActiveState)
+	s.Equal(uint32(1), request.NumFills)
+	request, err = s.Keeper.getRequest(s.Context, partial)
+	s.SDKNil(err)
+	s.True(request.ActiveState)
+	s.Equal(uint32(0), request.NumFills)
+
+	// only the fills that are still on the best chain are kept
+	kept := []types.Fill{
+		{ID: first, TxID: main[0].Hash, ConfirmingDigest: main[0].Hash},
+		{ID: cancelled, TxID: main[0].Hash, ConfirmingDigest: main[0].Hash},
+	}
+	s.Equal(kept, s.Keeper.getAllFills(s.Context))
+	s.Equal(kept, s.Keeper.getAllRequestFills(s.Context))
+
+	// emitted for every orphaned fill, from the old tip down
+	var reopened []string
+	for _, event := range s.Context.EventManager().Events() {
+		if event.Type == types.EventTypeRequestReopened {
+			s.Equal(types.AttributeKeyConfirmingBlock, string(event.Attributes[2].Key))
+			reopened = append(reopened, string(event.Attributes[2].Value))
+		}
+	}
+	s.Equal([]string{
+		"0x" + hex.EncodeToString(main[3].Hash[:]),
+		"0x" + hex.EncodeToString(main[3].Hash[:]),
+		"0x" + hex.EncodeToString(main[2].Hash[:]),
+		"0x" + hex.EncodeToString(main[2].Hash[:]),
+	}, reopened)
+}
+
+func (s *KeeperSuite) TestGetProvenTx() {
+	_, main := s.initPruneTest(4)
+	txid := types.Hash256Digest{1}
+	for i := 0; i < 3; i++ {
+		s.SDKNil(s.Keeper.setRequest(s.Context, nil, []byte{}, []byte{}, 0, 0, types.Local, nil, types.RequestOptions{}))
+	}
+	first := types.RequestID{0, 0, 0, 0, 0, 0, 0, 0}
+	third := types.RequestID{0, 0, 0, 0, 0, 0, 0, 2}
+
+	// errors if the transaction has filled no requests
+	_, err := s.Keeper.GetProvenTx(s.Context, txid)
+	s.Equal(sdk.CodeType(types.UnknownProvenTx), err.Code())
+
+	filled := types.FilledRequests{
+		Proof:  types.SPVProof{TxID: txid, ConfirmingHeader: main[1]},
+		Filled: []types.FilledRequestInfo{{ID: third}, {ID: first}},
+	}
+	s.SDKNil(s.Keeper.fillRequests(s.Context, nil, filled))
+
+	// returns the confirming header and the filled requests, ordered by ID
+	proven, err := s.Keeper.GetProvenTx(s.Context, txid)
+	s.SDKNil(err)
+	s.Equal(txid, proven.TxID)
+	s.Equal(main[1].Hash, proven.ConfirmingDigest)
+	s.Equal(main[1], proven.ConfirmingHeader)
+	s.False(proven.Pruned)
+	s.Equal(2, len(proven.Requests))
+	s.Equal(first, proven.Requests[0].ID)
+	s.Equal(third, proven.Requests[1].ID)
+	s.False(proven.Requests[0].Request.ActiveState)
+
+	// keeps the confirming digest and the requests once the header is pruned
+	headerStore := s.Keeper.getHeaderStore(s.Context)
+	buf := headerStore.Get(main[1].Hash[:])
+	headerStore.Delete(main[1].Hash[:])
+	pruned, err := s.Keeper.GetProvenTx(s.Context, txid)
+	s.SDKNil(err)
+	s.Equal(main[1].Hash, pruned.ConfirmingDigest)
+	s.Equal(types.BitcoinHeader{}, pruned.ConfirmingHeader)
+	s.True(pruned.Pruned)
+	s.Equal(proven.Requests, pruned.Requests)
+	headerStore.Set(main[1].Hash[:], buf)
+
+	// forgets fills that leave the best chain
+	s.SDKNil(s.Keeper.reopenOrphanedFills(s.Context, []types.Hash256Digest{main[1].Hash}))
+	_, err = s.Keeper.GetProvenTx(s.Context, txid)
+	s.Equal(sdk.CodeType(types.UnknownProvenTx), err.Code())
+}
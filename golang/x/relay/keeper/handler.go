@@ -21,6 +21,8 @@ func NewHandler(keeper Keeper) sdk.Handler {
 			return handleMsgNewRequest(ctx, keeper, msg)
 		case types.MsgProvideProof:
 			return handleMsgProvideProof(ctx, keeper, msg)
+		case types.MsgCancelRequest:
+			return handleMsgCancelRequest(ctx, keeper, msg)
 		default:
 			errMsg := fmt.Sprintf("Unrecognized relay Msg type: %v", msg.Type())
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -29,6 +31,17 @@ func NewHandler(keeper Keeper) sdk.Handler {
 }
 
 func handleMsgIngestHeaderChain(ctx sdk.Context, keeper Keeper, msg types.MsgIngestHeaderChain) sdk.Result {
+	// Chains that arrive before their anchor wait in the orphan pool
+	if !keeper.HasHeader(ctx, msg.Headers[0].PrevHash) {
+		err := keeper.AddOrphanChain(ctx, msg.Signer, msg.Headers)
+		if err != nil {
+			return err.Result()
+		}
+		return sdk.Result{
+			Events: ctx.EventManager().Events(),
+		}
+	}
+
 	err := keeper.IngestHeaderChain(ctx, msg.Headers)
 	if err != nil {
 		return err.Result()
@@ -64,10 +77,19 @@ func handleMsgNewRequest(ctx sdk.Context, keeper Keeper, msg types.MsgNewRequest
 	if err != nil {
 		return err.Result()
 	}
+	err = keeper.validateRequestLengths(ctx, msg.Spends, msg.Pays, msg.Action)
+	if err != nil {
+		return err.Result()
+	}
+
+	err = keeper.validateRequestExpiry(ctx, msg.ExpiryHeight, msg.BTCExpiryHeight)
+	if err != nil {
+		return err.Result()
+	}
 
 	// TODO: Add more complex permissioning
 	// Set request
-	err = keeper.setRequest(ctx, msg.Spends, msg.Pays, msg.PaysValue, msg.NumConfs, msg.Origin, msg.Action)
+	err = keeper.setRequest(ctx, msg.Signer, msg.Spends, msg.Pays, msg.PaysValue, msg.NumConfs, msg.Origin, msg.Action, msg.Options())
 	if err != nil {
 		return err.Result()
 	}
@@ -83,6 +105,11 @@ func handleMsgProvideProof(ctx sdk.Context, keeper Keeper, msg types.MsgProvideP
 		return err.Result()
 	}
 
+	err = keeper.fillRequests(ctx, msg.Signer, msg.Filled)
+	if err != nil {
+		return err.Result()
+	}
+
 	// Dispatch the proof to the keeper's proof handler
 	keeper.ProofHandler.HandleValidProof(ctx, msg.Filled, filled)
 
@@ -90,3 +117,13 @@ func handleMsgProvideProof(ctx sdk.Context, keeper Keeper, msg types.MsgProvideP
 		Events: ctx.EventManager().Events(),
 	}
 }
+
+func handleMsgCancelRequest(ctx sdk.Context, keeper Keeper, msg types.MsgCancelRequest) sdk.Result {
+	err := keeper.CancelRequest(ctx, msg.Signer, msg.ID)
+	if err != nil {
+		return err.Result()
+	}
+	return sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}
+}
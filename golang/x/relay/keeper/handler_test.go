@@ -113,14 +113,41 @@ func (s *KeeperSuite) TestHandleNewRequest() {
 	handler := NewHandler(s.Keeper)
 
 	// Success
-	newRequest := types.NewMsgNewRequest(getAccAddress(), bytes.Repeat([]byte{0}, 36), []byte{0}, 0, 0, types.Local, nil)
+	newRequest := types.NewMsgNewRequest(getAccAddress(), bytes.Repeat([]byte{0}, 36), []byte{0}, 0, 0, types.Local, nil, types.RequestOptions{})
 	res := handler(s.Context, newRequest)
 	hasRequest := s.Keeper.hasRequest(s.Context, types.RequestID{})
 	s.Equal(true, hasRequest)
 	s.Equal("proof_request", res.Events[0].Type)
 
+	// Stores the fill limit
+	newRequest = types.NewMsgNewRequest(getAccAddress(), []byte{}, []byte{0}, 0, 0, types.Local, nil, types.RequestOptions{MaxFills: 5})
+	res = handler(s.Context, newRequest)
+	s.True(res.IsOK())
+	request, err := s.Keeper.getRequest(s.Context, types.RequestID{0, 0, 0, 0, 0, 0, 0, 1})
+	s.SDKNil(err)
+	s.Equal(uint32(5), request.MaxFills)
+
+	// Stores the expiry heights
+	newRequest = types.NewMsgNewRequest(getAccAddress(), []byte{}, []byte{0}, 0, 0, types.Local, nil, types.RequestOptions{ExpiryHeight: 10})
+	res = handler(s.Context, newRequest)
+	s.True(res.IsOK())
+	request, err = s.Keeper.getRequest(s.Context, types.RequestID{0, 0, 0, 0, 0, 0, 0, 2})
+	s.SDKNil(err)
+	s.Equal(int64(10), request.ExpiryHeight)
+
+	// Expiry has already passed
+	newRequest = types.NewMsgNewRequest(getAccAddress(), []byte{}, []byte{0}, 0, 0, types.Local, nil, types.RequestOptions{ExpiryHeight: -1})
+	res = handler(s.Context, newRequest)
+	s.Equal(sdk.CodeType(types.ExpiryPassed), res.Code)
+
+	// Invalid bounty
+	bounty := sdk.Coins{sdk.Coin{Denom: "stake", Amount: sdk.NewInt(-1)}}
+	newRequest = types.NewMsgNewRequest(getAccAddress(), []byte{}, []byte{0}, 0, 0, types.Local, nil, types.RequestOptions{Bounty: bounty})
+	res = handler(s.Context, newRequest)
+	s.Equal(sdk.CodeInvalidCoins, res.Code)
+
 	// Msg validation failed
-	newRequest = types.NewMsgNewRequest(getAccAddress(), []byte{0}, []byte{0}, 0, 0, types.Local, nil)
+	newRequest = types.NewMsgNewRequest(getAccAddress(), []byte{0}, []byte{0}, 0, 0, types.Local, nil, types.RequestOptions{})
 	res = handler(s.Context, newRequest)
 	s.Equal(sdk.CodeType(types.SpendsLength), res.Code)
 
@@ -128,7 +155,24 @@ func (s *KeeperSuite) TestHandleNewRequest() {
 	store := s.Keeper.getRequestStore(s.Context)
 	store.Set([]byte(types.RequestIDTag), []byte("badID"))
 
-	newRequest = types.NewMsgNewRequest(getAccAddress(), bytes.Repeat([]byte{0}, 36), []byte{0}, 0, 0, types.Local, nil)
+	newRequest = types.NewMsgNewRequest(getAccAddress(), bytes.Repeat([]byte{0}, 36), []byte{0}, 0, 0, types.Local, nil, types.RequestOptions{})
 	res = handler(s.Context, newRequest)
 	s.Equal(sdk.CodeType(types.BadHexLen), res.Code)
 }
+
+func (s *KeeperSuite) TestHandleCancelRequest() {
+	handler := NewHandler(s.Keeper)
+
+	// errors if request is not found
+	cancelRequest := types.NewMsgCancelRequest(getAccAddress(), types.RequestID{})
+	res := handler(s.Context, cancelRequest)
+	s.Equal(sdk.CodeType(types.UnknownRequest), res.Code)
+
+	// Success
+	newRequest := types.NewMsgNewRequest(getAccAddress(), []byte{}, []byte{0}, 0, 0, types.Local, nil, types.RequestOptions{})
+	res = handler(s.Context, newRequest)
+	s.True(res.IsOK())
+	res = handler(s.Context, cancelRequest)
+	s.True(res.IsOK())
+	s.Equal("request_cancelled", res.Events[len(res.Events)-1].Type)
+}
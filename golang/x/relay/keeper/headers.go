@@ -1,6 +1,7 @@
 package keeper
 
 import (
+	"encoding/binary"
 	"math/big"
 
 	"github.com/summa-tx/bitcoin-spv/golang/btcspv"
@@ -24,17 +25,71 @@ func (k Keeper) HasHeader(ctx sdk.Context, digestLE types.Hash256Digest) bool {
 
 // GetHeader retrieves a header from the store using its LE diges
 func (k Keeper) GetHeader(ctx sdk.Context, digestLE types.Hash256Digest) (types.BitcoinHeader, sdk.Error) {
-	var header types.BitcoinHeader
 	store := k.getHeaderStore(ctx)
 
-	if !store.Has(digestLE[:]) {
+	buf := store.Get(digestLE[:])
+	if buf == nil {
 		return types.BitcoinHeader{}, types.ErrUnknownBlock(types.DefaultCodespace, "digest", digestLE)
 	}
 
-	buf := store.Get(digestLE[:])
-	k.cdc.MustUnmarshalBinaryBare(buf, &header)
+	return k.decodeHeader(buf), nil
+}
+
+// encodeHeader serializes a header as its 80 raw bytes followed by its
+// height as a uvarint. The hash, parent and merkle root are all in the raw
+// header, so they are recomputed on read instead of stored
+func encodeHeader(header types.BitcoinHeader) []byte {
+	buf := make([]byte, 80+binary.MaxVarintLen32)
+	copy(buf, header.Raw[:])
+	n := binary.PutUvarint(buf[80:], uint64(header.Height))
+	return buf[:80+n]
+}
+
+// isCompactHeader checks whether a stored header uses the compact encoding.
+// Legacy amino encoded headers are always longer
+func isCompactHeader(buf []byte) bool {
+	return len(buf) <= 80+binary.MaxVarintLen32
+}
+
+// decodeHeader deserializes a stored header. It accepts both the compact
+// encoding and the legacy amino encoding that migrateHeaderStore replaces
+func (k Keeper) decodeHeader(buf []byte) types.BitcoinHeader {
+	if !isCompactHeader(buf) {
+		var header types.BitcoinHeader
+		k.cdc.MustUnmarshalBinaryBare(buf, &header)
+		return header
+	}
+
+	var raw types.RawHeader
+	copy(raw[:], buf[:80])
+	// Can only fail if data store is corrupt
+	height, _ := binary.Uvarint(buf[80:])
+	return btcspv.HeaderFromRaw(raw, uint32(height))
+}
 
-	return header, nil
+// deleteHeader removes a pruned header along with its link and work
+func (k Keeper) deleteHeader(ctx sdk.Context, digestLE types.Hash256Digest) {
+	store := k.getHeaderStore(ctx)
+	store.Delete(digestLE[:])
+	k.deleteLink(ctx, digestLE)
+	k.deleteChainWork(ctx, digestLE)
+}
+
+// getAllHeaders returns every header in the store, ordered by LE digest
+func (k Keeper) getAllHeaders(ctx sdk.Context) []types.BitcoinHeader {
+	store := k.getHeaderStore(ctx)
+	iterator := sdk.KVStorePrefixIterator(store, nil)
+	defer iterator.Close()
+
+	headers := []types.BitcoinHeader{}
+	for ; iterator.Valid(); iterator.Next() {
+		// The header store also holds the epoch difficulties. Skip them
+		if len(iterator.Key()) != 32 {
+			continue
+		}
+		headers = append(headers, k.decodeHeader(iterator.Value()))
+	}
+	return headers
 }
 
 // getCurrentEpochDifficulty gets the current epoch's difficulty
@@ -87,6 +142,38 @@ func (k Keeper) setPrevEpochDifficulty(ctx sdk.Context, diff sdk.Uint) sdk.Error
 	return nil
 }
 
+// GetCurrentEpochDifficulty returns the difficulty of the best chain's
+// current epoch
+func (k Keeper) GetCurrentEpochDifficulty(ctx sdk.Context) (sdk.Uint, sdk.Error) {
+	_, err := k.GetRelayGenesis(ctx)
+	if err != nil {
+		return sdk.Uint{}, err
+	}
+	return k.getCurrentEpochDifficulty(ctx), nil
+}
+
+// GetPrevEpochDifficulty returns the difficulty of the epoch before the best
+// chain's current epoch. It is 0 until the relay has seen a retarget
+func (k Keeper) GetPrevEpochDifficulty(ctx sdk.Context) (sdk.Uint, sdk.Error) {
+	_, err := k.GetRelayGenesis(ctx)
+	if err != nil {
+		return sdk.Uint{}, err
+	}
+	if !k.getHeaderStore(ctx).Has([]byte(types.PrevEpochDiffStorage)) {
+		return sdk.ZeroUint(), nil
+	}
+	return k.getPrevEpochDifficulty(ctx), nil
+}
+
+// FindHeight returns the height of a stored header
+func (k Keeper) FindHeight(ctx sdk.Context, digestLE types.Hash256Digest) (uint32, sdk.Error) {
+	header, err := k.GetHeader(ctx, digestLE)
+	if err != nil {
+		return 0, err
+	}
+	return header.Height, nil
+}
+
 // updatePrevEpochDifficulty checks if there is a change in difficulty and updates
 // the previous epoch's difficulty accordingly
 func (k Keeper) updatePrevEpochDifficulty(ctx sdk.Context, oldDiff sdk.Uint) sdk.Error {
@@ -120,14 +207,73 @@ func compareTargets(full, truncated sdk.Uint) bool {
 // ingestHeader stores a Bitcoin Header
 func (k Keeper) ingestHeader(ctx sdk.Context, header types.BitcoinHeader) {
 	store := k.getHeaderStore(ctx)
+	store.Set(header.Hash[:], encodeHeader(header))
+}
+
+// truncateTarget drops the precision a header's nBits field cannot encode
+func truncateTarget(target sdk.Uint) sdk.Uint {
+	t := target.BigInt()
+	size := (t.BitLen() + 7) / 8
+	if size <= 3 {
+		return target
+	}
 
-	buf := k.cdc.MustMarshalBinaryBare(header)
-	store.Set(header.Hash[:], buf)
+	shift := uint(8 * (size - 3))
+	// nBits mantissas are signed. A set high bit costs a byte of precision
+	if new(big.Int).Rsh(t, shift).Bit(23) == 1 {
+		shift += 8
+	}
+	t.Rsh(t, shift)
+	t.Lsh(t, shift)
+	return sdk.NewUintFromBigInt(t)
+}
+
+// isMinDifficulty checks whether a header was mined at the network's PowLimit
+func isMinDifficulty(params types.ChainParams, header types.BitcoinHeader) bool {
+	return btcspv.ExtractTarget(header.Raw).Equal(truncateTarget(params.PowLimit))
+}
+
+// nextRealTarget returns the target that ordinary blocks after header must
+// use, given realTarget, the one that applied to header. A min difficulty
+// block leaves it unchanged, unless it starts a retarget period
+func nextRealTarget(params types.ChainParams, header types.BitcoinHeader, realTarget sdk.Uint) sdk.Uint {
+	if header.Height%params.RetargetInterval == 0 || !isMinDifficulty(params, header) {
+		return btcspv.ExtractTarget(header.Raw)
+	}
+	return realTarget
 }
 
-// validateHeaderChain validates a chain of Bitcoin Headers
-func validateHeaderChain(anchor types.BitcoinHeader, headers []types.BitcoinHeader, internal, isMainnet bool) sdk.Error {
+// validateMinDifficulty checks a header against the testnet rules. A block
+// more than twice the target spacing after its parent must be mined at the
+// PowLimit. Any other block must use the target of the last block that was
+// not, walking back no further than the start of the retarget period
+func validateMinDifficulty(params types.ChainParams, prev, header types.BitcoinHeader, realTarget sdk.Uint) sdk.Error {
+	// Targets may only change through ingestDifficultyChange
+	if header.Height%params.RetargetInterval == 0 {
+		return types.ErrUnexpectedRetarget(types.DefaultCodespace, header.Raw)
+	}
+
+	gap := uint(2 * params.TargetSpacing)
+	if btcspv.ExtractTimestamp(header.Raw) > btcspv.ExtractTimestamp(prev.Raw)+gap {
+		if !isMinDifficulty(params, header) {
+			return types.ErrBadMinDifficulty(types.DefaultCodespace, header.Raw)
+		}
+		return nil
+	}
+
+	if !btcspv.ExtractTarget(header.Raw).Equal(realTarget) {
+		return types.ErrUnexpectedRetarget(types.DefaultCodespace, header.Raw)
+	}
+	return nil
+}
+
+// validateHeaderChain validates a chain of Bitcoin Headers. anchorRealTarget
+// is the target of the last block at or before the anchor that was not mined
+// under the min difficulty exception. It is only used by networks that allow
+// min difficulty blocks
+func validateHeaderChain(params types.ChainParams, anchor types.BitcoinHeader, anchorRealTarget sdk.Uint, headers []types.BitcoinHeader, internal bool) sdk.Error {
 	prev := anchor // scratchpad, we change this later
+	realTarget := anchorRealTarget
 
 	// On internal call, use the header chain target
 	expectedTarget := btcspv.ExtractTarget(anchor.Raw)
@@ -150,9 +296,18 @@ func validateHeaderChain(anchor types.BitcoinHeader, headers []types.BitcoinHead
 			return types.ErrHeightMismatch(types.DefaultCodespace, prev.Hash, header.Hash)
 		}
 
-		// ensure expectedTarget doesn't change
-		// it's allowed to change if the relay is in testnet mode
-		if isMainnet && !btcspv.ExtractTarget(header.Raw).Equal(expectedTarget) {
+		if params.AllowMinDifficultyBlocks {
+			// The first header of an internal call is a retarget, and is
+			// checked by validateDifficultyChange
+			if !internal || i != 0 {
+				sdkErr := validateMinDifficulty(params, prev, header, realTarget)
+				if sdkErr != nil {
+					return sdkErr
+				}
+			}
+			realTarget = nextRealTarget(params, header, realTarget)
+		} else if !btcspv.ExtractTarget(header.Raw).Equal(expectedTarget) {
+			// ensure expectedTarget doesn't change
 			return types.ErrUnexpectedRetarget(types.DefaultCodespace, header.Raw)
 		}
 
@@ -171,6 +326,21 @@ func validateHeaderChain(anchor types.BitcoinHeader, headers []types.BitcoinHead
 	return nil
 }
 
+// getRealTarget walks back from a header to the last block that was not mined
+// under the min difficulty exception, stopping at the start of the retarget
+// period or the bottom of the relay, and returns its target
+func (k Keeper) getRealTarget(ctx sdk.Context, params types.ChainParams, header types.BitcoinHeader) sdk.Uint {
+	current := header
+	for current.Height%params.RetargetInterval != 0 && isMinDifficulty(params, current) {
+		prev, err := k.GetHeader(ctx, current.PrevHash)
+		if err != nil {
+			break
+		}
+		current = prev
+	}
+	return btcspv.ExtractTarget(current.Raw)
+}
+
 // ingestHeaders validates and stores a chain of Bitcoin Headers
 func (k Keeper) ingestHeaders(ctx sdk.Context, headers []types.BitcoinHeader, internal bool) sdk.Error {
 	anchor, err := k.GetHeader(ctx, headers[0].PrevHash)
@@ -178,36 +348,104 @@ func (k Keeper) ingestHeaders(ctx sdk.Context, headers []types.BitcoinHeader, in
 		return err
 	}
 
-	err = validateHeaderChain(anchor, headers, internal, k.IsMainNet)
+	params := k.GetChainParams(ctx)
+	err = validateHeaderChain(params, anchor, k.getRealTarget(ctx, params, anchor), headers, internal)
+	if err != nil {
+		return err
+	}
+
+	err = validateTimestamps(k.getTimestampWindow(ctx, anchor), headers, ctx.BlockHeader().Time.Unix())
+	if err != nil {
+		return err
+	}
+
+	err = k.checkHeadersAgainstCheckpoints(ctx, headers)
 	if err != nil {
 		return err
 	}
 
+	lastKnown := k.hasLink(ctx, headers[len(headers)-1].Hash)
+	work := k.getChainWork(ctx, anchor.Hash)
 	for _, header := range headers {
+		work = work.Add(calculateWork(header.Raw))
+		err = k.setChainWork(ctx, header.Hash, work)
+		if err != nil {
+			return err
+		}
 		k.setLink(ctx, header)
 		k.ingestHeader(ctx, header)
 	}
+	k.updateTips(ctx, anchor, headers, lastKnown)
 
 	k.emitExtension(ctx, anchor, headers[len(headers)-1])
+	k.afterChainExtended(ctx, headers)
 
 	return nil
 }
 
+// retargetAlgorithm calculates the target of a new retarget period from the
+// target and timestamps of the previous one, capped at the network's PowLimit
+func retargetAlgorithm(params types.ChainParams, previousTarget sdk.Uint, firstTimestamp, secondTimestamp uint) sdk.Uint {
+	if params.NoRetargeting {
+		return previousTarget
+	}
+
+	timespan := params.TargetTimespan()
+	lowerBound := timespan / 4
+	upperBound := timespan * 4
+
+	elapsedTime := uint64(0)
+	if secondTimestamp > firstTimestamp {
+		elapsedTime = uint64(secondTimestamp - firstTimestamp)
+	}
+	if elapsedTime > upperBound {
+		elapsedTime = upperBound
+	}
+	if elapsedTime < lowerBound {
+		elapsedTime = lowerBound
+	}
+
+	target := previousTarget.MulUint64(elapsedTime).QuoUint64(timespan)
+	if target.GT(params.PowLimit) {
+		return params.PowLimit
+	}
+	return target
+}
+
 // validateDifficultyChange validates a Header Chain with a difficulty change
-func validateDifficultyChange(headers []types.BitcoinHeader, prevEpochStart, anchor types.BitcoinHeader) sdk.Error {
-	if anchor.Height%2016 != 2015 {
+func validateDifficultyChange(params types.ChainParams, headers []types.BitcoinHeader, prevEpochStart, anchor types.BitcoinHeader) sdk.Error {
+	interval := params.RetargetInterval
+	if anchor.Height%interval != interval-1 {
 		return types.ErrWrongEnd(types.DefaultCodespace)
 	}
-	if anchor.Height != prevEpochStart.Height+2015 || anchor.Height < prevEpochStart.Height {
+	if anchor.Height != prevEpochStart.Height+interval-1 || anchor.Height < prevEpochStart.Height {
 		return types.ErrWrongStart(types.DefaultCodespace)
 	}
-	if !btcspv.ExtractDifficulty(anchor.Raw).Equal(btcspv.ExtractDifficulty(prevEpochStart.Raw)) {
+	// The last block of a period may be a min difficulty block
+	if !params.AllowMinDifficultyBlocks && !btcspv.ExtractDifficulty(anchor.Raw).Equal(btcspv.ExtractDifficulty(prevEpochStart.Raw)) {
 		return types.ErrPeriodMismatch(types.DefaultCodespace)
 	}
+	// BIP94 stops the timewarp attack by bounding how far the first block of
+	// a period can be timestamped before the last block of the previous one
+	if params.EnforceBIP94 {
+		timestamp := btcspv.ExtractTimestamp(headers[0].Raw)
+		anchorTimestamp := btcspv.ExtractTimestamp(anchor.Raw)
+		if timestamp+maxTimewarp < anchorTimestamp {
+			return types.ErrTimewarp(types.DefaultCodespace, headers[0].Raw, timestamp, maxTimewarp, anchorTimestamp)
+		}
+	}
+
+	// testnet3 retargets from the target of the last block of the period,
+	// even if it is a min difficulty block. BIP94 uses the first block
+	previousTarget := btcspv.ExtractTarget(prevEpochStart.Raw)
+	if params.AllowMinDifficultyBlocks && !params.EnforceBIP94 {
+		previousTarget = btcspv.ExtractTarget(anchor.Raw)
+	}
 
 	// calculated target
-	expectedTarget := btcspv.RetargetAlgorithm(
-		btcspv.ExtractTarget(prevEpochStart.Raw),
+	expectedTarget := retargetAlgorithm(
+		params,
+		previousTarget,
 		btcspv.ExtractTimestamp(prevEpochStart.Raw),
 		btcspv.ExtractTimestamp(anchor.Raw))
 
@@ -233,7 +471,7 @@ func (k Keeper) ingestDifficultyChange(ctx sdk.Context, prevEpochStartLE types.H
 		return err
 	}
 
-	err = validateDifficultyChange(headers, prevEpochStart, anchor)
+	err = validateDifficultyChange(k.GetChainParams(ctx), headers, prevEpochStart, anchor)
 	if err != nil {
 		return err
 	}
@@ -247,12 +485,29 @@ func (k Keeper) ingestDifficultyChange(ctx sdk.Context, prevEpochStartLE types.H
 	return k.ingestHeaders(ctx, headers, true)
 }
 
+// maybeAdvanceBestTip advances the best known digest to the end of a newly
+// ingested chain when the keeper is configured to do so
+func (k Keeper) maybeAdvanceBestTip(ctx sdk.Context, headers []types.BitcoinHeader) sdk.Error {
+	if !k.AutoAdvance {
+		return nil
+	}
+	return k.advanceBestTip(ctx, headers[len(headers)-1], k.GetParams(ctx).MaxLookupLimit)
+}
+
 // IngestHeaderChain ingests a chain of headers
 func (k Keeper) IngestHeaderChain(ctx sdk.Context, headers []types.BitcoinHeader) sdk.Error {
-	return k.ingestHeaders(ctx, headers, false)
+	err := k.ingestHeaders(ctx, headers, false)
+	if err != nil {
+		return err
+	}
+	return k.maybeAdvanceBestTip(ctx, headers)
 }
 
 // IngestDifficultyChange ingests a chain of headers
 func (k Keeper) IngestDifficultyChange(ctx sdk.Context, prevEpochStartLE types.Hash256Digest, headers []types.BitcoinHeader) sdk.Error {
-	return k.ingestDifficultyChange(ctx, prevEpochStartLE, headers)
+	err := k.ingestDifficultyChange(ctx, prevEpochStartLE, headers)
+	if err != nil {
+		return err
+	}
+	return k.maybeAdvanceBestTip(ctx, headers)
 }
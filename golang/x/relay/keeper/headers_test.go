@@ -1,7 +1,10 @@
 package keeper
 
 import (
+	"encoding/binary"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/summa-tx/bitcoin-spv/golang/btcspv"
 	"github.com/summa-tx/relays/golang/x/relay/types"
 )
 
@@ -26,7 +29,7 @@ func (s *KeeperSuite) TestValidateHeaderChain() {
 	cases := s.Fixtures.HeaderTestCases.ValidateChain
 
 	for _, tc := range cases {
-		err := validateHeaderChain(tc.Anchor, tc.Headers, tc.Internal, tc.IsMainnet)
+		err := validateHeaderChain(testChainParams(tc.IsMainnet), tc.Anchor, btcspv.ExtractTarget(tc.Anchor.Raw), tc.Headers, tc.Internal)
 		if tc.Output == 0 {
 			logIfTestCaseError(tc, err)
 			s.SDKNil(err)
@@ -37,6 +40,82 @@ func (s *KeeperSuite) TestValidateHeaderChain() {
 	}
 }
 
+// mineHeader builds a header on prev with the given timestamp and nBits, and
+// grinds the nonce until it meets its target
+func mineHeader(prev types.BitcoinHeader, timestamp, bits uint32) types.BitcoinHeader {
+	return mineHeaderWithRoot(prev, types.Hash256Digest{}, timestamp, bits)
+}
+
+// mineHeaderWithRoot mines a header that commits to a merkle root
+func mineHeaderWithRoot(prev types.BitcoinHeader, root types.Hash256Digest, timestamp, bits uint32) types.BitcoinHeader {
+	var raw types.RawHeader
+	binary.LittleEndian.PutUint32(raw[0:4], 0x20000000)
+	copy(raw[4:36], prev.Hash[:])
+	copy(raw[36:68], root[:])
+	binary.LittleEndian.PutUint32(raw[68:72], timestamp)
+	binary.LittleEndian.PutUint32(raw[72:76], bits)
+	for nonce := uint32(0); ; nonce++ {
+		binary.LittleEndian.PutUint32(raw[76:80], nonce)
+		if btcspv.ValidateHeaderWork(btcspv.Hash256(raw[:]), btcspv.ExtractTarget(raw)) {
+			return btcspv.HeaderFromRaw(raw, prev.Height+1)
+		}
+	}
+}
+
+func (s *KeeperSuite) TestTruncateTarget() {
+	params := types.MainnetParams()
+	s.Equal(params.GenesisHeader.Raw[72:76], []byte{0xff, 0xff, 0x00, 0x1d})
+	s.Equal(btcspv.ExtractTarget(params.GenesisHeader.Raw), truncateTarget(params.PowLimit))
+	s.True(isMinDifficulty(params, params.GenesisHeader))
+
+	params = types.RegtestParams()
+	s.True(isMinDifficulty(params, params.GenesisHeader))
+}
+
+func (s *KeeperSuite) TestValidateMinDifficulty() {
+	// regtest's easy PowLimit with testnet's retargeting
+	params := types.RegtestParams()
+	params.NoRetargeting = false
+	const minBits = 0x207fffff
+	const realBits = 0x1f00ffff
+	anchor := mineHeader(params.GenesisHeader, 1000000, realBits)
+	realTarget := btcspv.ExtractTarget(anchor.Raw)
+
+	// a block within 20 minutes of its parent uses the real target
+	ok := mineHeader(anchor, 1000600, realBits)
+	s.SDKNil(validateHeaderChain(params, anchor, realTarget, []types.BitcoinHeader{ok}, false))
+	easy := mineHeader(anchor, 1000600, minBits)
+	err := validateHeaderChain(params, anchor, realTarget, []types.BitcoinHeader{easy}, false)
+	s.Equal(sdk.CodeType(types.UnexpectedRetarget), err.Code())
+
+	// a block more than 20 minutes after its parent must use the PowLimit
+	late := mineHeader(anchor, 1001201, minBits)
+	s.SDKNil(validateHeaderChain(params, anchor, realTarget, []types.BitcoinHeader{late}, false))
+	late = mineHeader(anchor, 1001201, realBits)
+	err = validateHeaderChain(params, anchor, realTarget, []types.BitcoinHeader{late}, false)
+	s.Equal(sdk.CodeType(types.BadMinDifficulty), err.Code())
+
+	// after a min difficulty block, the chain returns to the real target
+	late = mineHeader(anchor, 1001201, minBits)
+	next := mineHeader(late, 1001300, realBits)
+	s.SDKNil(validateHeaderChain(params, anchor, realTarget, []types.BitcoinHeader{late, next}, false))
+	next = mineHeader(late, 1001300, minBits)
+	err = validateHeaderChain(params, anchor, realTarget, []types.BitcoinHeader{late, next}, false)
+	s.Equal(sdk.CodeType(types.UnexpectedRetarget), err.Code())
+
+	// the real target is recovered from the store
+	s.Keeper.ingestHeader(s.Context, anchor)
+	s.Keeper.ingestHeader(s.Context, late)
+	s.Equal(realTarget, s.Keeper.getRealTarget(s.Context, params, late))
+
+	// retargets are never accepted outside ingestDifficultyChange
+	boundary := anchor
+	boundary.Height = params.RetargetInterval - 1
+	retarget := mineHeader(boundary, 1001201, minBits)
+	err = validateHeaderChain(params, boundary, realTarget, []types.BitcoinHeader{retarget}, false)
+	s.Equal(sdk.CodeType(types.UnexpectedRetarget), err.Code())
+}
+
 func (s *KeeperSuite) TestIngestHeaders() {
 	cases := s.Fixtures.HeaderTestCases.ValidateChain
 
@@ -87,15 +166,35 @@ func (s *KeeperSuite) TestIngestHeader() {
 		s.Equal(true, hasHeader)
 		header, err := s.Keeper.GetHeader(s.Context, tc.Headers[0].Hash)
 		s.SDKNil(err)
-		s.Equal(tc.Headers[0], header)
+		// Only the raw header and height are stored. Some vectors carry a
+		// deliberately wrong merkle root, which is recomputed from the raw
+		s.Equal(btcspv.HeaderFromRaw(tc.Headers[0].Raw, tc.Headers[0].Height), header)
+		_, validateErr := tc.Headers[0].Validate()
+		if validateErr == nil {
+			s.Equal(tc.Headers[0], header)
+		}
 	}
 }
 
+func (s *KeeperSuite) TestEncodeHeader() {
+	header := s.Fixtures.HeaderTestCases.ValidateChain[0].Headers[0]
+
+	buf := encodeHeader(header)
+	s.True(isCompactHeader(buf))
+	s.Equal(header.Raw[:], buf[:80])
+	s.Equal(header, s.Keeper.decodeHeader(buf))
+
+	// legacy amino headers still decode
+	legacy := s.Keeper.cdc.MustMarshalBinaryBare(header)
+	s.False(isCompactHeader(legacy))
+	s.Equal(header, s.Keeper.decodeHeader(legacy))
+}
+
 func (s *KeeperSuite) TestValidateDifficultyChange() {
 	cases := s.Fixtures.HeaderTestCases.ValidateDiffChange
 
 	for _, tc := range cases {
-		err := validateDifficultyChange(tc.Headers, tc.PrevEpochStart, tc.Anchor)
+		err := validateDifficultyChange(types.MainnetParams(), tc.Headers, tc.PrevEpochStart, tc.Anchor)
 		if tc.Output == 0 {
 			logIfTestCaseError(tc, err)
 			s.SDKNil(err)
@@ -106,6 +205,29 @@ func (s *KeeperSuite) TestValidateDifficultyChange() {
 	}
 }
 
+func (s *KeeperSuite) TestValidateTimewarp() {
+	params := types.RegtestParams()
+	params.RetargetInterval = 4
+	params.EnforceBIP94 = true
+	genesis := params.GenesisHeader
+	period := mineChain(genesis, 3, 600)
+	anchor := period[2]
+	timestamp := uint32(btcspv.ExtractTimestamp(anchor.Raw))
+
+	// the first block of a period may be up to 600 seconds before its parent
+	headers := []types.BitcoinHeader{mineHeader(anchor, timestamp-600, 0x207fffff)}
+	s.SDKNil(validateDifficultyChange(params, headers, genesis, anchor))
+
+	// errors if it is any earlier
+	headers = []types.BitcoinHeader{mineHeader(anchor, timestamp-601, 0x207fffff)}
+	err := validateDifficultyChange(params, headers, genesis, anchor)
+	s.Equal(sdk.CodeType(types.Timewarp), err.Code())
+
+	// only enforced under BIP94
+	params.EnforceBIP94 = false
+	s.SDKNil(validateDifficultyChange(params, headers, genesis, anchor))
+}
+
 func (s *KeeperSuite) TestIngestDifficultyChange() {
 	cases := s.Fixtures.HeaderTestCases.ValidateDiffChange
 
@@ -132,6 +254,85 @@ func (s *KeeperSuite) TestIngestDifficultyChange() {
 	}
 }
 
+func (s *KeeperSuite) TestIngestAutoAdvance() {
+	tv := s.Fixtures.ChainTestCases.IsMostRecentCA
+	pre := tv.PreRetargetChain
+	post := tv.PostRetargetChain
+	var postWithOrphan []types.BitcoinHeader
+	postWithOrphan = append(postWithOrphan, post[:len(post)-2]...)
+	postWithOrphan = append(postWithOrphan, tv.Orphan)
+
+	err := s.Keeper.SetGenesisState(s.Context, tv.Genesis, tv.OldPeriodStart)
+	s.SDKNil(err)
+
+	// does not move the best known digest unless enabled
+	err = s.Keeper.IngestHeaderChain(s.Context, pre[:2])
+	s.SDKNil(err)
+	best, err := s.Keeper.GetBestKnownDigest(s.Context)
+	s.SDKNil(err)
+	s.Equal(tv.Genesis.Hash, best)
+
+	s.Keeper.AutoAdvance = true
+
+	// extends the best chain
+	err = s.Keeper.IngestHeaderChain(s.Context, pre[2:])
+	s.SDKNil(err)
+	best, err = s.Keeper.GetBestKnownDigest(s.Context)
+	s.SDKNil(err)
+	s.Equal(pre[len(pre)-1].Hash, best)
+	lca, err := s.Keeper.GetLastReorgLCA(s.Context)
+	s.SDKNil(err)
+	s.Equal(tv.Genesis.Hash, lca)
+
+	events := s.Context.EventManager().Events()
+	s.Equal("reorg", events[len(events)-1].Type)
+
+	// updates the epoch difficulty across a retarget
+	err = s.Keeper.IngestDifficultyChange(s.Context, tv.OldPeriodStart.Hash, post)
+	s.SDKNil(err)
+	best, err = s.Keeper.GetBestKnownDigest(s.Context)
+	s.SDKNil(err)
+	s.Equal(post[len(post)-1].Hash, best)
+	s.Equal(btcspv.ExtractDifficulty(post[0].Raw), s.Keeper.getCurrentEpochDifficulty(s.Context))
+	digest, err := s.Keeper.GetDigestByHeight(s.Context, post[0].Height)
+	s.SDKNil(err)
+	s.Equal(post[0].Hash, digest)
+
+	// ignores a lighter fork
+	err = s.Keeper.IngestDifficultyChange(s.Context, tv.OldPeriodStart.Hash, postWithOrphan)
+	s.SDKNil(err)
+	best, err = s.Keeper.GetBestKnownDigest(s.Context)
+	s.SDKNil(err)
+	s.Equal(post[len(post)-1].Hash, best)
+	lca, err = s.Keeper.GetLastReorgLCA(s.Context)
+	s.SDKNil(err)
+	s.Equal(pre[len(pre)-1].Hash, lca)
+}
+
+func (s *KeeperSuite) TestIngestAutoAdvanceLookupLimit() {
+	genesis, main := s.initPruneTest(4)
+	fork := mineChain(genesis, 6, 601)
+
+	// does not look further back than the max lookup limit
+	params := s.Keeper.GetParams(s.Context)
+	params.MaxLookupLimit = 4
+	params.DefaultLookupLimit = 4
+	s.Keeper.SetParams(s.Context, params)
+	err := s.Keeper.IngestHeaderChain(s.Context, fork[:5])
+	s.SDKNil(err)
+	best, err := s.Keeper.GetBestKnownDigest(s.Context)
+	s.SDKNil(err)
+	s.Equal(main[3].Hash, best)
+
+	// moves to a fork based further back than one epoch
+	s.Keeper.SetParams(s.Context, types.DefaultParams())
+	err = s.Keeper.IngestHeaderChain(s.Context, fork[5:])
+	s.SDKNil(err)
+	best, err = s.Keeper.GetBestKnownDigest(s.Context)
+	s.SDKNil(err)
+	s.Equal(fork[5].Hash, best)
+}
+
 func (s *KeeperSuite) TestCompareTargets() {
 	cases := s.Fixtures.HeaderTestCases.CompareTargets
 
@@ -160,3 +361,60 @@ func (s *KeeperSuite) TestSetPrevEpochDiff() {
 
 	s.Equal(d, val)
 }
+
+func (s *KeeperSuite) TestGetEpochDifficulties() {
+	tv := s.Fixtures.ChainTestCases.IsMostRecentCA
+	pre := tv.PreRetargetChain
+	post := tv.PostRetargetChain
+
+	// errors before the relay is initialized
+	_, err := s.Keeper.GetCurrentEpochDifficulty(s.Context)
+	s.Equal(sdk.CodeType(types.BadHash256Digest), err.Code())
+	_, err = s.Keeper.GetPrevEpochDifficulty(s.Context)
+	s.Equal(sdk.CodeType(types.BadHash256Digest), err.Code())
+
+	err = s.Keeper.SetGenesisState(s.Context, tv.Genesis, tv.OldPeriodStart)
+	s.SDKNil(err)
+
+	// the previous difficulty is 0 until a retarget
+	genesisDiff := btcspv.ExtractDifficulty(tv.Genesis.Raw)
+	diff, err := s.Keeper.GetCurrentEpochDifficulty(s.Context)
+	s.SDKNil(err)
+	s.Equal(genesisDiff, diff)
+	diff, err = s.Keeper.GetPrevEpochDifficulty(s.Context)
+	s.SDKNil(err)
+	s.Equal(sdk.ZeroUint(), diff)
+
+	// moving the best chain past a retarget updates both
+	err = s.Keeper.IngestHeaderChain(s.Context, pre)
+	s.SDKNil(err)
+	err = s.Keeper.IngestDifficultyChange(s.Context, tv.OldPeriodStart.Hash, post)
+	s.SDKNil(err)
+	tip := post[len(post)-1]
+	err = s.Keeper.MarkNewHeaviest(s.Context, tv.Genesis.Hash, tv.Genesis.Raw, tip.Raw, 20)
+	s.SDKNil(err)
+	diff, err = s.Keeper.GetCurrentEpochDifficulty(s.Context)
+	s.SDKNil(err)
+	s.Equal(btcspv.ExtractDifficulty(tip.Raw), diff)
+	diff, err = s.Keeper.GetPrevEpochDifficulty(s.Context)
+	s.SDKNil(err)
+	s.Equal(genesisDiff, diff)
+}
+
+func (s *KeeperSuite) TestFindHeight() {
+	tv := s.Fixtures.ChainTestCases.IsMostRecentCA
+
+	// errors on unknown headers
+	_, err := s.Keeper.FindHeight(s.Context, tv.Genesis.Hash)
+	s.Equal(sdk.CodeType(types.UnknownBlock), err.Code())
+
+	err = s.Keeper.SetGenesisState(s.Context, tv.Genesis, tv.OldPeriodStart)
+	s.SDKNil(err)
+	err = s.Keeper.IngestHeaderChain(s.Context, tv.PreRetargetChain)
+	s.SDKNil(err)
+
+	// finds headers off the best chain
+	height, err := s.Keeper.FindHeight(s.Context, tv.PreRetargetChain[2].Hash)
+	s.SDKNil(err)
+	s.Equal(tv.PreRetargetChain[2].Height, height)
+}
@@ -0,0 +1,220 @@
+package keeper
+
+import (
+	"encoding/binary"
+
+	"github.com/summa-tx/bitcoin-spv/golang/btcspv"
+	"github.com/summa-tx/relays/golang/x/relay/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func (k Keeper) getHeightStore(ctx sdk.Context) sdk.KVStore {
+	return k.getPrefixStore(ctx, types.HeightStorePrefix)
+}
+
+// heightKey encodes a height as big-endian so that the store iterates in height order
+func heightKey(height uint32) []byte {
+	key := make([]byte, 4)
+	binary.BigEndian.PutUint32(key, height)
+	return key
+}
+
+// setHeightDigest indexes a best chain digest by its height
+func (k Keeper) setHeightDigest(ctx sdk.Context, height uint32, digestLE types.Hash256Digest) {
+	store := k.getHeightStore(ctx)
+	store.Set(heightKey(height), digestLE[:])
+}
+
+// deleteHeightDigest removes the best chain digest at a height
+func (k Keeper) deleteHeightDigest(ctx sdk.Context, height uint32) {
+	store := k.getHeightStore(ctx)
+	store.Delete(heightKey(height))
+}
+
+// GetDigestByHeight returns the digest of the best chain block at a height
+func (k Keeper) GetDigestByHeight(ctx sdk.Context, height uint32) (types.Hash256Digest, sdk.Error) {
+	store := k.getHeightStore(ctx)
+	buf := store.Get(heightKey(height))
+	if buf == nil {
+		return types.Hash256Digest{}, types.ErrUnknownHeight(types.DefaultCodespace, height)
+	}
+
+	// Can only fail if data store is corrupt
+	digest, _ := btcspv.NewHash256Digest(buf)
+	return digest, nil
+}
+
+// GetHeaderByHeight returns the best chain header at a height
+func (k Keeper) GetHeaderByHeight(ctx sdk.Context, height uint32) (types.BitcoinHeader, sdk.Error) {
+	digest, err := k.GetDigestByHeight(ctx, height)
+	if err != nil {
+		return types.BitcoinHeader{}, err
+	}
+	return k.GetHeader(ctx, digest)
+}
+
+// isInBestChain checks whether a header is indexed on the best chain
+func (k Keeper) isInBestChain(ctx sdk.Context, header types.BitcoinHeader) bool {
+	digest, err := k.GetDigestByHeight(ctx, header.Height)
+	return err == nil && digest == header.Hash
+}
+
+// GetHeaders returns up to count consecutive best chain headers, starting at
+// a height. It stops early at the best known digest. A count of 0 uses the
+// default lookup limit
+func (k Keeper) GetHeaders(ctx sdk.Context, height, count uint32) ([]types.BitcoinHeader, sdk.Error) {
+	count = k.lookupLimit(ctx, count)
+	maxLimit := k.GetParams(ctx).MaxLookupLimit
+	if count > maxLimit {
+		return nil, types.ErrLimitTooHigh(types.DefaultCodespace, count, maxLimit)
+	}
+
+	headers := k.getBestChainRange(ctx, height, count)
+	if len(headers) == 0 {
+		return nil, types.ErrUnknownHeight(types.DefaultCodespace, height)
+	}
+	return headers, nil
+}
+
+// getBestChainRange reads up to count best chain headers from a height. It
+// is empty if no block is indexed at the height
+func (k Keeper) getBestChainRange(ctx sdk.Context, height, count uint32) []types.BitcoinHeader {
+	headers := []types.BitcoinHeader{}
+	for i := uint32(0); i < count; i++ {
+		header, err := k.GetHeaderByHeight(ctx, height+i)
+		if err != nil {
+			break
+		}
+		headers = append(headers, header)
+	}
+	return headers
+}
+
+// GetHeadersFromDigest returns up to count consecutive best chain headers,
+// starting at a best chain block
+func (k Keeper) GetHeadersFromDigest(ctx sdk.Context, digestLE types.Hash256Digest, count uint32) ([]types.BitcoinHeader, sdk.Error) {
+	start, err := k.GetHeader(ctx, digestLE)
+	if err != nil {
+		return nil, err
+	}
+	if !k.isInBestChain(ctx, start) {
+		return nil, types.ErrNotInBestChain(types.DefaultCodespace, digestLE)
+	}
+	return k.GetHeaders(ctx, start.Height, count)
+}
+
+// LocateHeaders finds the highest digest of a block locator that is on the
+// best chain, and returns it along with up to count best chain headers after
+// it. Locator digests that are unknown or off the best chain are skipped
+func (k Keeper) LocateHeaders(ctx sdk.Context, locator []types.Hash256Digest, count uint32) (types.LocatedHeaders, sdk.Error) {
+	count = k.lookupLimit(ctx, count)
+	maxLimit := k.GetParams(ctx).MaxLookupLimit
+	if count > maxLimit {
+		return types.LocatedHeaders{}, types.ErrLimitTooHigh(types.DefaultCodespace, count, maxLimit)
+	}
+
+	found := false
+	var fork types.BitcoinHeader
+	for _, digest := range locator {
+		header, err := k.GetHeader(ctx, digest)
+		if err != nil || !k.isInBestChain(ctx, header) {
+			continue
+		}
+		if !found || header.Height > fork.Height {
+			fork = header
+			found = true
+		}
+	}
+	if !found {
+		return types.LocatedHeaders{}, types.ErrNoLocatorMatch(types.DefaultCodespace, len(locator))
+	}
+
+	return types.LocatedHeaders{
+		Fork:    fork,
+		Headers: k.getBestChainRange(ctx, fork.Height+1, count),
+	}, nil
+}
+
+// getAllHeightDigests returns the whole height index, ordered by height
+func (k Keeper) getAllHeightDigests(ctx sdk.Context) []types.HeightDigest {
+	store := k.getHeightStore(ctx)
+	iterator := sdk.KVStorePrefixIterator(store, nil)
+	defer iterator.Close()
+
+	entries := []types.HeightDigest{}
+	for ; iterator.Valid(); iterator.Next() {
+		// Can only fail if data store is corrupt
+		digest, _ := btcspv.NewHash256Digest(iterator.Value())
+		entries = append(entries, types.HeightDigest{
+			Height: binary.BigEndian.Uint32(iterator.Key()),
+			Digest: digest,
+		})
+	}
+	return entries
+}
+
+// reindexBestChain rewrites the height index after the best chain tip moves
+// from prevBest to newBest. Entries from newBest back to the ancestor are
+// (re)written, and entries above newBest left over from a longer previous
+// chain are removed.
+func (k Keeper) reindexBestChain(ctx sdk.Context, ancestor types.BitcoinHeader, prevBest, newBest types.BitcoinHeader) {
+	current := newBest.Hash
+	for height := newBest.Height; height > ancestor.Height; height-- {
+		k.setHeightDigest(ctx, height, current)
+		current = k.getLink(ctx, current)
+	}
+	k.setHeightDigest(ctx, ancestor.Height, ancestor.Hash)
+
+	for height := newBest.Height + 1; height <= prevBest.Height; height++ {
+		k.deleteHeightDigest(ctx, height)
+	}
+}
+
+// rebuildHeightIndex recomputes the height index by walking the links back
+// from the best known digest to the relay genesis
+func (k Keeper) rebuildHeightIndex(ctx sdk.Context) sdk.Error {
+	for _, entry := range k.getAllHeightDigests(ctx) {
+		k.deleteHeightDigest(ctx, entry.Height)
+	}
+
+	relayGenesis, err := k.GetRelayGenesis(ctx)
+	if err != nil {
+		return err
+	}
+	genesis, err := k.GetHeader(ctx, relayGenesis)
+	if err != nil {
+		return err
+	}
+	bestKnown, err := k.GetBestKnownDigest(ctx)
+	if err != nil {
+		return err
+	}
+	best, err := k.GetHeader(ctx, bestKnown)
+	if err != nil {
+		return err
+	}
+
+	k.reindexBestChain(ctx, genesis, best, best)
+	return nil
+}
+
+// findBestChainAncestor walks back from a header until it reaches a block in
+// the height index, which is the LCA of the header and the best chain
+func (k Keeper) findBestChainAncestor(ctx sdk.Context, header types.BitcoinHeader, limit uint32) (types.BitcoinHeader, bool) {
+	current := header
+	for i := uint32(0); i <= limit; i++ {
+		digest, err := k.GetDigestByHeight(ctx, current.Height)
+		if err == nil && digest == current.Hash {
+			return current, true
+		}
+		if !k.hasLink(ctx, current.Hash) {
+			return types.BitcoinHeader{}, false
+		}
+		current, err = k.GetHeader(ctx, current.PrevHash)
+		if err != nil {
+			return types.BitcoinHeader{}, false
+		}
+	}
+	return types.BitcoinHeader{}, false
+}
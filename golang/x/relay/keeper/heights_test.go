@@ -0,0 +1,144 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/summa-tx/relays/golang/x/relay/types"
+)
+
+func (s *KeeperSuite) TestHeightIndex() {
+	tv := s.Fixtures.ChainTestCases.IsMostRecentCA
+	pre := tv.PreRetargetChain
+	post := tv.PostRetargetChain
+	var postWithOrphan []types.BitcoinHeader
+	postWithOrphan = append(postWithOrphan, post[:len(post)-2]...)
+	postWithOrphan = append(postWithOrphan, tv.Orphan)
+
+	// errors before genesis is set
+	_, err := s.Keeper.GetDigestByHeight(s.Context, tv.Genesis.Height)
+	s.Equal(sdk.CodeType(types.UnknownHeight), err.Code())
+
+	err = s.Keeper.SetGenesisState(s.Context, tv.Genesis, tv.OldPeriodStart)
+	s.SDKNil(err)
+
+	// genesis is indexed
+	header, err := s.Keeper.GetHeaderByHeight(s.Context, tv.Genesis.Height)
+	s.SDKNil(err)
+	s.Equal(tv.Genesis, header)
+	_, err = s.Keeper.GetHeaderByHeight(s.Context, pre[0].Height)
+	s.Equal(sdk.CodeType(types.UnknownHeight), err.Code())
+
+	err = s.Keeper.IngestHeaderChain(s.Context, pre)
+	s.SDKNil(err)
+	err = s.Keeper.IngestDifficultyChange(s.Context, tv.OldPeriodStart.Hash, post)
+	s.SDKNil(err)
+	err = s.Keeper.IngestDifficultyChange(s.Context, tv.OldPeriodStart.Hash, postWithOrphan)
+	s.SDKNil(err)
+
+	// ingesting does not move the index
+	_, err = s.Keeper.GetHeaderByHeight(s.Context, pre[0].Height)
+	s.Equal(sdk.CodeType(types.UnknownHeight), err.Code())
+
+	// extending to the orphan indexes every block back to genesis
+	err = s.Keeper.MarkNewHeaviest(s.Context, tv.Genesis.Hash, tv.Genesis.Raw, tv.Orphan.Raw, 20)
+	s.SDKNil(err)
+	expected := append(append([]types.BitcoinHeader{tv.Genesis}, pre...), postWithOrphan...)
+	for _, h := range expected {
+		digest, err := s.Keeper.GetDigestByHeight(s.Context, h.Height)
+		s.SDKNil(err)
+		s.Equal(h.Hash, digest)
+	}
+
+	// reorging to the main chain overwrites the orphan
+	tip := post[len(post)-1]
+	err = s.Keeper.MarkNewHeaviest(s.Context, post[5].Hash, tv.Orphan.Raw, tip.Raw, 20)
+	s.SDKNil(err)
+	for _, h := range post {
+		digest, err := s.Keeper.GetDigestByHeight(s.Context, h.Height)
+		s.SDKNil(err)
+		s.Equal(h.Hash, digest)
+	}
+
+	// moving to a shorter chain drops the stale heights
+	s.Keeper.reindexBestChain(s.Context, post[5], tip, tv.Orphan)
+	digest, err := s.Keeper.GetDigestByHeight(s.Context, tv.Orphan.Height)
+	s.SDKNil(err)
+	s.Equal(tv.Orphan.Hash, digest)
+	_, err = s.Keeper.GetDigestByHeight(s.Context, tip.Height)
+	s.Equal(sdk.CodeType(types.UnknownHeight), err.Code())
+}
+
+func (s *KeeperSuite) TestGetHeaders() {
+	genesis, main := s.initPruneTest(6)
+
+	// errors when no block is indexed at the start
+	_, err := s.Keeper.GetHeaders(s.Context, genesis.Height+7, 2)
+	s.Equal(sdk.CodeType(types.UnknownHeight), err.Code())
+
+	// errors if the count is above the max lookup limit
+	_, err = s.Keeper.GetHeaders(s.Context, genesis.Height, 2017)
+	s.Equal(sdk.CodeType(types.LimitTooHigh), err.Code())
+
+	// returns consecutive headers with their heights
+	headers, err := s.Keeper.GetHeaders(s.Context, genesis.Height, 3)
+	s.SDKNil(err)
+	s.Equal([]types.BitcoinHeader{genesis, main[0], main[1]}, headers)
+
+	// stops at the best known digest
+	headers, err = s.Keeper.GetHeaders(s.Context, main[3].Height, 10)
+	s.SDKNil(err)
+	s.Equal(main[3:], headers)
+
+	// a count of 0 uses the default lookup limit
+	headers, err = s.Keeper.GetHeaders(s.Context, genesis.Height, 0)
+	s.SDKNil(err)
+	s.Equal(7, len(headers))
+
+	// starts from a best chain digest
+	headers, err = s.Keeper.GetHeadersFromDigest(s.Context, main[1].Hash, 2)
+	s.SDKNil(err)
+	s.Equal(main[1:3], headers)
+
+	// errors on unknown and fork digests
+	fork := mineChain(main[3], 2, 300)
+	err = s.Keeper.IngestHeaderChain(s.Context, fork)
+	s.SDKNil(err)
+	_, err = s.Keeper.GetHeadersFromDigest(s.Context, fork[0].Hash, 2)
+	s.Equal(sdk.CodeType(types.NotInBestChain), err.Code())
+	_, err = s.Keeper.GetHeadersFromDigest(s.Context, types.Hash256Digest{1}, 2)
+	s.Equal(sdk.CodeType(types.UnknownBlock), err.Code())
+}
+
+func (s *KeeperSuite) TestLocateHeaders() {
+	genesis, main := s.initPruneTest(6)
+	fork := mineChain(main[1], 2, 300)
+	err := s.Keeper.IngestHeaderChain(s.Context, fork)
+	s.SDKNil(err)
+
+	// errors if no digest is on the best chain
+	_, err = s.Keeper.LocateHeaders(s.Context, []types.Hash256Digest{fork[1].Hash, {1}}, 2)
+	s.Equal(sdk.CodeType(types.NoLocatorMatch), err.Code())
+
+	// errors if the count is above the max lookup limit
+	_, err = s.Keeper.LocateHeaders(s.Context, []types.Hash256Digest{genesis.Hash}, 2017)
+	s.Equal(sdk.CodeType(types.LimitTooHigh), err.Code())
+
+	// skips unknown and fork digests, and returns the headers after the fork
+	locator := []types.Hash256Digest{{1}, fork[1].Hash, main[1].Hash, main[0].Hash, genesis.Hash}
+	located, err := s.Keeper.LocateHeaders(s.Context, locator, 2)
+	s.SDKNil(err)
+	s.Equal(main[1], located.Fork)
+	s.Equal(main[2:4], located.Headers)
+
+	// picks the highest digest wherever it is in the locator
+	locator = []types.Hash256Digest{genesis.Hash, main[3].Hash}
+	located, err = s.Keeper.LocateHeaders(s.Context, locator, 0)
+	s.SDKNil(err)
+	s.Equal(main[3], located.Fork)
+	s.Equal(main[4:], located.Headers)
+
+	// returns no headers from the best digest
+	located, err = s.Keeper.LocateHeaders(s.Context, []types.Hash256Digest{main[5].Hash}, 2)
+	s.SDKNil(err)
+	s.Equal(main[5], located.Fork)
+	s.Equal(0, len(located.Headers))
+}
@@ -0,0 +1,33 @@
+package keeper
+
+import (
+	"github.com/summa-tx/relays/golang/x/relay/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// afterChainExtended calls the AfterChainExtended hook, if hooks are set
+func (k Keeper) afterChainExtended(ctx sdk.Context, headers []types.BitcoinHeader) {
+	if k.hooks != nil {
+		k.hooks.AfterChainExtended(ctx, headers)
+	}
+}
+
+// afterReorg calls the AfterReorg hook, if hooks are set
+func (k Keeper) afterReorg(ctx sdk.Context, ancestor, prevBest, newBest types.BitcoinHeader, orphaned []types.Hash256Digest) {
+	if k.hooks != nil {
+		k.hooks.AfterReorg(ctx, prevBest.Hash, newBest.Hash, ancestor.Hash, orphaned)
+	}
+}
+
+// getOrphaned returns the blocks from prevBest down to the block above the
+// ancestor. These leave the best chain when it moves to another branch
+func (k Keeper) getOrphaned(ctx sdk.Context, ancestor, prevBest types.BitcoinHeader) []types.Hash256Digest {
+	orphaned := []types.Hash256Digest{}
+	current := prevBest.Hash
+	for height := prevBest.Height; height > ancestor.Height; height-- {
+		orphaned = append(orphaned, current)
+		current = k.getLink(ctx, current)
+	}
+	return orphaned
+}
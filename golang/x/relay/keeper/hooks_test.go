@@ -0,0 +1,74 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/summa-tx/relays/golang/x/relay/types"
+)
+
+type reorgCall struct {
+	prevBest, newBest, lca types.Hash256Digest
+	orphaned               []types.Hash256Digest
+}
+
+// recordingHooks records every hook call
+type recordingHooks struct {
+	extended [][]types.BitcoinHeader
+	reorgs   []reorgCall
+}
+
+func (h *recordingHooks) AfterChainExtended(ctx sdk.Context, headers []types.BitcoinHeader) {
+	h.extended = append(h.extended, headers)
+}
+
+func (h *recordingHooks) AfterReorg(ctx sdk.Context, prevBest, newBest, lca types.Hash256Digest, orphaned []types.Hash256Digest) {
+	h.reorgs = append(h.reorgs, reorgCall{prevBest, newBest, lca, orphaned})
+}
+
+func (s *KeeperSuite) TestSetHooks() {
+	s.Keeper.SetHooks(&recordingHooks{})
+	s.Panics(func() { s.Keeper.SetHooks(&recordingHooks{}) })
+}
+
+func (s *KeeperSuite) TestHooks() {
+	tv := s.Fixtures.ChainTestCases.IsMostRecentCA
+	pre := tv.PreRetargetChain
+	post := tv.PostRetargetChain
+	var postWithOrphan []types.BitcoinHeader
+	postWithOrphan = append(postWithOrphan, post[:len(post)-2]...)
+	postWithOrphan = append(postWithOrphan, tv.Orphan)
+
+	first, second := &recordingHooks{}, &recordingHooks{}
+	s.Keeper.SetHooks(types.NewMultiRelayHooks(first, second))
+
+	err := s.Keeper.SetGenesisState(s.Context, tv.Genesis, tv.OldPeriodStart)
+	s.SDKNil(err)
+	err = s.Keeper.IngestHeaderChain(s.Context, pre)
+	s.SDKNil(err)
+	err = s.Keeper.IngestDifficultyChange(s.Context, tv.OldPeriodStart.Hash, post)
+	s.SDKNil(err)
+	err = s.Keeper.IngestDifficultyChange(s.Context, tv.OldPeriodStart.Hash, postWithOrphan)
+	s.SDKNil(err)
+
+	// every ingestion is reported, to every hook
+	s.Equal([][]types.BitcoinHeader{pre, post, postWithOrphan}, first.extended)
+	s.Equal(first, second)
+	s.Equal(0, len(first.reorgs))
+
+	// failed ingestion is not reported
+	err = s.Keeper.IngestHeaderChain(s.Context, []types.BitcoinHeader{tv.Genesis})
+	s.NotNil(err)
+	s.Equal(3, len(first.extended))
+
+	// moving to a descendant orphans nothing
+	err = s.Keeper.MarkNewHeaviest(s.Context, tv.Genesis.Hash, tv.Genesis.Raw, tv.Orphan.Raw, 20)
+	s.SDKNil(err)
+	s.Equal([]reorgCall{{tv.Genesis.Hash, tv.Orphan.Hash, tv.Genesis.Hash, []types.Hash256Digest{}}}, first.reorgs)
+
+	// moving to another branch orphans the old tip
+	tip := post[len(post)-1]
+	lca := post[len(post)-3]
+	err = s.Keeper.MarkNewHeaviest(s.Context, lca.Hash, tv.Orphan.Raw, tip.Raw, 20)
+	s.SDKNil(err)
+	s.Equal(reorgCall{tv.Orphan.Hash, tip.Hash, lca.Hash, []types.Hash256Digest{tv.Orphan.Hash}}, first.reorgs[1])
+	s.Equal(first, second)
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/store/prefix"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/summa-tx/bitcoin-spv/golang/btcspv"
 
 	"github.com/summa-tx/relays/golang/x/relay/types"
@@ -11,22 +12,42 @@ import (
 
 // Keeper maintains the link to data storage and exposes getter/setter methods for the various parts of the state machine
 type Keeper struct {
-	storeKey     sdk.StoreKey // Unexposed key to access store from sdk.Context
-	cdc          *codec.Codec // The wire codec for binary encoding/decoding.
-	IsMainNet    bool
+	storeKey     sdk.StoreKey       // Unexposed key to access store from sdk.Context
+	cdc          *codec.Codec       // The wire codec for binary encoding/decoding.
+	paramSpace   params.Subspace    // The governance-tunable limits
+	supplyKeeper types.SupplyKeeper // Holds request bounties in the module account
+	hooks        types.RelayHooks   // Notified when the chain is extended or reorganized
+	AutoAdvance  bool               // Move the best known digest when ingested headers are heavier
 	ProofHandler types.ProofHandler
 }
 
 // NewKeeper instantiates a new keeper
-func NewKeeper(storeKey sdk.StoreKey, cdc *codec.Codec, mainnet bool, handler types.ProofHandler) Keeper {
+func NewKeeper(storeKey sdk.StoreKey, cdc *codec.Codec, paramSpace params.Subspace, supplyKeeper types.SupplyKeeper, autoAdvance bool, handler types.ProofHandler) Keeper {
+	// ensure the module account is set
+	if addr := supplyKeeper.GetModuleAddress(types.ModuleName); addr == nil {
+		panic("the relay module account has not been set")
+	}
+
 	return Keeper{
 		storeKey:     storeKey,
 		cdc:          cdc,
-		IsMainNet:    mainnet,
+		paramSpace:   paramSpace.WithKeyTable(types.ParamKeyTable()),
+		supplyKeeper: supplyKeeper,
+		AutoAdvance:  autoAdvance,
 		ProofHandler: handler,
 	}
 }
 
+// SetHooks sets the relay hooks. It must be called before the keeper is
+// passed to other modules, which hold copies of it
+func (k *Keeper) SetHooks(hooks types.RelayHooks) *Keeper {
+	if k.hooks != nil {
+		panic("cannot set relay hooks twice")
+	}
+	k.hooks = hooks
+	return k
+}
+
 func (k Keeper) getPrefixStore(ctx sdk.Context, namespace string) sdk.KVStore {
 	return prefix.NewStore(ctx.KVStore(k.storeKey), []byte(namespace))
 }
@@ -55,12 +76,143 @@ func (k Keeper) SetGenesisState(ctx sdk.Context, genesis, epochStart btcspv.Bitc
 	k.ingestHeader(ctx, genesis)
 	k.ingestHeader(ctx, epochStart)
 
+	k.setStoreVersion(ctx, currentStoreVersion)
 	k.setRelayGenesis(ctx, genesis.Hash)
 	k.setBestKnownDigest(ctx, genesis.Hash)
 	k.setLastReorgLCA(ctx, genesis.Hash)
+	k.setHeightDigest(ctx, genesis.Height, genesis.Hash)
+	k.setTip(ctx, genesis.Hash, genesis.Height)
+	k.setMMRBaseHeight(ctx, genesis.Height)
+	k.appendMMRLeaf(ctx, 0, genesis.Hash)
+
+	err := k.setChainWork(ctx, genesis.Hash, calculateWork(genesis.Raw))
+	if err != nil {
+		return err
+	}
 
 	// this will only fail if the genesis state is corrupt
 	_ = k.setCurrentEpochDifficulty(ctx, btcspv.ExtractDifficulty(genesis.Raw))
 
 	return nil
 }
+
+// ExportChainState returns a snapshot of all relay state
+func (k Keeper) ExportChainState(ctx sdk.Context) (types.ChainState, sdk.Error) {
+	relayGenesis, err := k.GetRelayGenesis(ctx)
+	if err != nil {
+		return types.ChainState{}, err
+	}
+	bestKnown, err := k.GetBestKnownDigest(ctx)
+	if err != nil {
+		return types.ChainState{}, err
+	}
+	lca, err := k.GetLastReorgLCA(ctx)
+	if err != nil {
+		return types.ChainState{}, err
+	}
+	requests, err := k.getAllRequests(ctx)
+	if err != nil {
+		return types.ChainState{}, err
+	}
+	nextID, err := k.getNextID(ctx)
+	if err != nil {
+		return types.ChainState{}, err
+	}
+
+	// The previous epoch difficulty is not set until the first retarget
+	prevDiff := sdk.ZeroUint()
+	if k.getHeaderStore(ctx).Has([]byte(types.PrevEpochDiffStorage)) {
+		prevDiff = k.getPrevEpochDifficulty(ctx)
+	}
+
+	return types.ChainState{
+		RelayGenesis:           relayGenesis,
+		BestKnownDigest:        bestKnown,
+		LastReorgLCA:           lca,
+		CurrentEpochDifficulty: k.getCurrentEpochDifficulty(ctx),
+		PrevEpochDifficulty:    prevDiff,
+		Headers:                k.getAllHeaders(ctx),
+		Links:                  k.getAllLinks(ctx),
+		Heights:                k.getAllHeightDigests(ctx),
+		ChainWork:              k.getAllChainWork(ctx),
+		Requests:               requests,
+		Fills:                  k.getAllFills(ctx),
+		RequestFills:           k.getAllRequestFills(ctx),
+		Orphans:                k.getAllOrphanChains(ctx),
+		MMRBaseHeight:          k.getMMRBaseHeight(ctx),
+		MMRNodes:               k.getAllMMRNodes(ctx),
+		NextRequestID:          nextID,
+	}, nil
+}
+
+// ImportChainState rebuilds the store from a snapshot made by ExportChainState
+func (k Keeper) ImportChainState(ctx sdk.Context, state types.ChainState) sdk.Error {
+	if k.hasRelayGenesis(ctx) {
+		return types.ErrAlreadyInit(types.DefaultCodespace)
+	}
+
+	for _, header := range state.Headers {
+		k.ingestHeader(ctx, header)
+	}
+	for _, link := range state.Links {
+		k.setLinkDigests(ctx, link)
+	}
+	for _, entry := range state.Heights {
+		k.setHeightDigest(ctx, entry.Height, entry.Digest)
+	}
+	for _, entry := range state.ChainWork {
+		err := k.setChainWork(ctx, entry.Digest, entry.ChainWork)
+		if err != nil {
+			return err
+		}
+	}
+
+	k.setStoreVersion(ctx, currentStoreVersion)
+	k.setRelayGenesis(ctx, state.RelayGenesis)
+	k.setBestKnownDigest(ctx, state.BestKnownDigest)
+	k.setLastReorgLCA(ctx, state.LastReorgLCA)
+
+	err := k.rebuildTips(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = k.setCurrentEpochDifficulty(ctx, state.CurrentEpochDifficulty)
+	if err != nil {
+		return err
+	}
+	if !state.PrevEpochDifficulty.IsZero() {
+		err = k.setPrevEpochDifficulty(ctx, state.PrevEpochDifficulty)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, r := range state.Requests {
+		err = k.storeRequest(ctx, r.ID, r.Request)
+		if err != nil {
+			return err
+		}
+	}
+	k.setNextID(ctx, state.NextRequestID)
+	for _, fill := range state.Fills {
+		k.setFill(ctx, fill)
+	}
+	for _, fill := range state.RequestFills {
+		k.setRequestFill(ctx, fill)
+	}
+	for _, orphan := range state.Orphans {
+		k.setOrphanChain(ctx, orphan)
+	}
+
+	// Snapshots from before the MMR was added are rebuilt from the heights
+	if len(state.MMRNodes) == 0 {
+		return k.rebuildMMR(ctx)
+	}
+	k.setMMRBaseHeight(ctx, state.MMRBaseHeight)
+	for _, node := range state.MMRNodes {
+		k.setMMRNode(ctx, node)
+	}
+
+	return nil
+}
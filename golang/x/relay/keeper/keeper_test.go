@@ -7,11 +7,13 @@ import (
 	"log"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/staking"
 	"github.com/cosmos/cosmos-sdk/x/supply"
@@ -188,9 +190,10 @@ type KeeperTestCases struct {
 
 type KeeperSuite struct {
 	suite.Suite
-	Fixtures KeeperTestCases
-	Context  sdk.Context
-	Keeper   Keeper
+	Fixtures   KeeperTestCases
+	Context    sdk.Context
+	Keeper     Keeper
+	BankKeeper bank.Keeper
 }
 
 func (c Case) Name() string {
@@ -232,12 +235,32 @@ func (s *KeeperSuite) InitTestContext(mainnet, isCheckTx bool) {
 	}
 
 	cdc := codec.New()
-
-	ctx := sdk.NewContext(ms, abci.Header{ChainID: "relayTestChain"}, isCheckTx, tmlog.NewNopLogger())
-	keeper := NewKeeper(relayKey, cdc, mainnet, types.NewNullHandler())
+	auth.RegisterCodec(cdc)
+	supply.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "relayTestChain", Time: time.Now()}, isCheckTx, tmlog.NewNopLogger())
+	paramsKeeper := params.NewKeeper(cdc, keyParams, tkeyParams, params.DefaultCodespace)
+	accountKeeper := auth.NewAccountKeeper(cdc, keyAcc, paramsKeeper.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
+	bankKeeper := bank.NewBaseKeeper(accountKeeper, paramsKeeper.Subspace(bank.DefaultParamspace), bank.DefaultCodespace, nil)
+	maccPerms := map[string][]string{types.ModuleName: nil}
+	supplyKeeper := supply.NewKeeper(cdc, keySupply, accountKeeper, bankKeeper, maccPerms)
+	keeper := NewKeeper(relayKey, cdc, paramsKeeper.Subspace(types.DefaultParamspace), supplyKeeper, false, types.NewNullHandler())
+	keeper.SetChainParams(ctx, testChainParams(mainnet))
+	keeper.SetParams(ctx, types.DefaultParams())
 
 	s.Context = ctx
 	s.Keeper = keeper
+	s.BankKeeper = bankKeeper
+}
+
+// testChainParams returns the chain parameters that test vectors labeled
+// mainnet or testnet are validated against
+func testChainParams(mainnet bool) types.ChainParams {
+	if mainnet {
+		return types.MainnetParams()
+	}
+	return types.Testnet3Params()
 }
 
 func (s *KeeperSuite) SetupTest() {
@@ -315,3 +338,86 @@ func (s *KeeperSuite) TestSetGenesisState() {
 	err = s.Keeper.SetGenesisState(s.Context, genesis, epochStart)
 	s.Equal(types.AlreadyInit, err.Code())
 }
+
+// checkExportImport checks that the store exports a valid snapshot, and that
+// importing it into a new store exports the same snapshot
+func (s *KeeperSuite) checkExportImport() {
+	exported, err := s.Keeper.ExportChainState(s.Context)
+	s.SDKNil(err)
+	s.Nil(exported.Validate())
+
+	chainParams := s.Keeper.GetChainParams(s.Context)
+	s.InitTestContext(true, false)
+	s.Keeper.SetChainParams(s.Context, chainParams)
+	s.SDKNil(s.Keeper.ImportChainState(s.Context, exported))
+	imported, err := s.Keeper.ExportChainState(s.Context)
+	s.SDKNil(err)
+	s.Equal(exported, imported)
+}
+
+func (s *KeeperSuite) TestExportImportChainState() {
+	tv := s.Fixtures.ChainTestCases.IsMostRecentCA
+	pre := tv.PreRetargetChain
+	post := tv.PostRetargetChain
+
+	// errors if there is no relay genesis
+	_, err := s.Keeper.ExportChainState(s.Context)
+	s.Equal(sdk.CodeType(types.BadHash256Digest), err.Code())
+
+	err = s.Keeper.SetGenesisState(s.Context, tv.Genesis, tv.OldPeriodStart)
+	s.SDKNil(err)
+	err = s.Keeper.IngestHeaderChain(s.Context, pre)
+	s.SDKNil(err)
+	err = s.Keeper.IngestDifficultyChange(s.Context, tv.OldPeriodStart.Hash, post)
+	s.SDKNil(err)
+	err = s.Keeper.MarkNewHeaviest(s.Context, tv.Genesis.Hash, tv.Genesis.Raw, pre[0].Raw, 10)
+	s.SDKNil(err)
+	err = s.Keeper.setRequest(s.Context, nil, []byte{0}, []byte{0}, 0, 4, types.Local, nil, types.RequestOptions{})
+	s.SDKNil(err)
+	err = s.Keeper.setRequest(s.Context, nil, []byte{1}, []byte{1}, 10, 0, types.Remote, []byte{1}, types.RequestOptions{})
+	s.SDKNil(err)
+	err = s.Keeper.setRequestState(s.Context, types.RequestID{}, false)
+	s.SDKNil(err)
+	s.Keeper.setFill(s.Context, types.Fill{ID: types.RequestID{}, TxID: pre[0].MerkleRoot, ConfirmingDigest: pre[0].Hash})
+	s.Keeper.setRequestFill(s.Context, types.Fill{ID: types.RequestID{}, TxID: pre[0].MerkleRoot, ConfirmingDigest: pre[0].Hash})
+
+	exported, err := s.Keeper.ExportChainState(s.Context)
+	s.SDKNil(err)
+	s.Equal(len(pre)+len(post)+2, len(exported.Headers))
+	s.Equal(len(pre)+len(post), len(exported.Links))
+	s.Equal(2, len(exported.Requests))
+	s.Equal(1, len(exported.Fills))
+	s.Equal(1, len(exported.RequestFills))
+	s.Equal(pre[0].Hash, exported.BestKnownDigest)
+
+	// survives a JSON round trip
+	bz, marshalErr := types.ModuleCdc.MarshalJSON(exported)
+	s.Nil(marshalErr)
+	var state types.ChainState
+	unmarshalErr := types.ModuleCdc.UnmarshalJSON(bz, &state)
+	s.Nil(unmarshalErr)
+
+	oldStore := s.Context.KVStore(s.Keeper.storeKey)
+
+	s.InitTestContext(true, false)
+	err = s.Keeper.ImportChainState(s.Context, state)
+	s.SDKNil(err)
+
+	// rebuilds an identical store
+	newStore := s.Context.KVStore(s.Keeper.storeKey)
+	oldIter := oldStore.Iterator(nil, nil)
+	newIter := newStore.Iterator(nil, nil)
+	defer oldIter.Close()
+	defer newIter.Close()
+	for ; oldIter.Valid(); oldIter.Next() {
+		s.True(newIter.Valid())
+		s.Equal(oldIter.Key(), newIter.Key())
+		s.Equal(oldIter.Value(), newIter.Value())
+		newIter.Next()
+	}
+	s.False(newIter.Valid())
+
+	// errors if the relay is already initialized
+	err = s.Keeper.ImportChainState(s.Context, state)
+	s.Equal(sdk.CodeType(types.AlreadyInit), err.Code())
+}
@@ -21,6 +21,11 @@ func (k Keeper) setLink(ctx sdk.Context, header types.BitcoinHeader) {
 	store.Set(header.Hash[:], header.PrevHash[:])
 }
 
+func (k Keeper) deleteLink(ctx sdk.Context, digestLE types.Hash256Digest) {
+	store := k.getLinkStore(ctx)
+	store.Delete(digestLE[:])
+}
+
 func (k Keeper) getLink(ctx sdk.Context, digestLE types.Hash256Digest) types.Hash256Digest {
 	store := k.getLinkStore(ctx)
 	buf := store.Get(digestLE[:])
@@ -29,6 +34,28 @@ func (k Keeper) getLink(ctx sdk.Context, digestLE types.Hash256Digest) types.Has
 	return parentHash
 }
 
+// getAllLinks returns every link in the store, ordered by LE digest
+func (k Keeper) getAllLinks(ctx sdk.Context) []types.Link {
+	store := k.getLinkStore(ctx)
+	iterator := sdk.KVStorePrefixIterator(store, nil)
+	defer iterator.Close()
+
+	links := []types.Link{}
+	for ; iterator.Valid(); iterator.Next() {
+		// Can only fail if data store is corrupt
+		digest, _ := btcspv.NewHash256Digest(iterator.Key())
+		parent, _ := btcspv.NewHash256Digest(iterator.Value())
+		links = append(links, types.Link{Digest: digest, Parent: parent})
+	}
+	return links
+}
+
+// setLinkDigests stores a link without requiring the full child header
+func (k Keeper) setLinkDigests(ctx sdk.Context, link types.Link) {
+	store := k.getLinkStore(ctx)
+	store.Set(link.Digest[:], link.Parent[:])
+}
+
 // FindAncestor finds the nth ancestor of some digest
 func (k Keeper) FindAncestor(ctx sdk.Context, digestLE types.Hash256Digest, offset uint32) (types.Hash256Digest, sdk.Error) {
 	current := digestLE
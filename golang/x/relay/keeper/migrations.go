@@ -0,0 +1,164 @@
+package keeper
+
+import (
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/summa-tx/relays/golang/x/relay/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Store layout versions. Each migration moves the store up by one version
+const (
+	// storeVersionLegacy is the original layout, with amino encoded headers
+	storeVersionLegacy uint32 = 0
+	// storeVersionCompactHeaders stores headers as raw bytes and a height
+	storeVersionCompactHeaders uint32 = 1
+	// storeVersionBinaryRequests stores requests with the amino binary
+	// encoding instead of JSON
+	storeVersionBinaryRequests uint32 = 2
+	// storeVersionParams adds the module params, which replaced hardcoded
+	// limits
+	storeVersionParams uint32 = 3
+	// storeVersionChainIndexes adds the indexes the legacy keeper did not
+	// keep: the best chain height index, the accumulated work of each
+	// header, the chain tips and the Merkle Mountain Range over the best chain
+	storeVersionChainIndexes uint32 = 4
+
+	// currentStoreVersion is the layout written by this version of the keeper
+	currentStoreVersion = storeVersionChainIndexes
+)
+
+// getStoreVersion returns the layout version of the store. Stores written
+// before versioning was introduced have no version and are legacy
+func (k Keeper) getStoreVersion(ctx sdk.Context) uint32 {
+	store := k.getChainStore(ctx)
+	buf := store.Get([]byte(types.StoreVersionStorage))
+	if buf == nil {
+		return storeVersionLegacy
+	}
+	return binary.BigEndian.Uint32(buf)
+}
+
+// setStoreVersion records the layout version of the store
+func (k Keeper) setStoreVersion(ctx sdk.Context, version uint32) {
+	store := k.getChainStore(ctx)
+	buf := make([]byte, 4)
+	binary.BigEndian.PutUint32(buf, version)
+	store.Set([]byte(types.StoreVersionStorage), buf)
+}
+
+// Migrate upgrades a store written by an older version of the keeper to the
+// current layout. It does nothing if the relay is not initialized or is
+// already current. It rewrites the whole store at once, so it runs when the
+// genesis is exported for an upgrade, not during block execution
+func (k Keeper) Migrate(ctx sdk.Context) sdk.Error {
+	if !k.hasRelayGenesis(ctx) {
+		return nil
+	}
+
+	for version := k.getStoreVersion(ctx); version < currentStoreVersion; version++ {
+		switch version {
+		case storeVersionLegacy:
+			k.migrateHeaderStore(ctx)
+		case storeVersionCompactHeaders:
+			err := k.migrateRequestStore(ctx)
+			if err != nil {
+				return err
+			}
+		case storeVersionBinaryRequests:
+			k.migrateParams(ctx)
+		case storeVersionParams:
+			err := k.rebuildChainIndexes(ctx)
+			if err != nil {
+				return err
+			}
+		}
+		k.setStoreVersion(ctx, version+1)
+	}
+	return nil
+}
+
+// migrateHeaderStore rewrites amino encoded headers in the compact encoding
+func (k Keeper) migrateHeaderStore(ctx sdk.Context) {
+	store := k.getHeaderStore(ctx)
+	iterator := sdk.KVStorePrefixIterator(store, nil)
+
+	var legacy []types.BitcoinHeader
+	for ; iterator.Valid(); iterator.Next() {
+		// The header store also holds the epoch difficulties. Skip them
+		if len(iterator.Key()) != 32 || isCompactHeader(iterator.Value()) {
+			continue
+		}
+		legacy = append(legacy, k.decodeHeader(iterator.Value()))
+	}
+	iterator.Close()
+
+	// The store can not be written while it is being iterated
+	for _, header := range legacy {
+		k.ingestHeader(ctx, header)
+	}
+}
+
+// migrateRequestStore rewrites JSON encoded requests in the binary encoding
+func (k Keeper) migrateRequestStore(ctx sdk.Context) sdk.Error {
+	store := k.getRequestStore(ctx)
+	iterator := sdk.KVStorePrefixIterator(store, nil)
+
+	var legacy []types.IdentifiedRequest
+	for ; iterator.Valid(); iterator.Next() {
+		// The request store also holds the ID counter. Skip it
+		if len(iterator.Key()) != 8 {
+			continue
+		}
+		var request types.ProofRequest
+		err := json.Unmarshal(iterator.Value(), &request)
+		if err != nil {
+			iterator.Close()
+			return types.ErrExternal(types.DefaultCodespace, err)
+		}
+		// Can only fail if the key is not 8 bytes, which was checked above
+		id, _ := types.NewRequestID(iterator.Key())
+		legacy = append(legacy, types.IdentifiedRequest{ID: id, Request: request})
+	}
+	iterator.Close()
+
+	// The store can not be written while it is being iterated
+	for _, r := range legacy {
+		err := k.storeRequest(ctx, r.ID, r.Request)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateParams sets the default params. The limits match those that were
+// hardcoded before params were introduced
+func (k Keeper) migrateParams(ctx sdk.Context) {
+	if k.paramSpace.Has(ctx, types.KeyProofAncestorLimit) {
+		return
+	}
+	k.SetParams(ctx, types.DefaultParams())
+}
+
+// rebuildChainIndexes builds the indexes over the stored headers that the
+// legacy keeper did not keep. It walks every stored header, so like the
+// other migrations it only runs from Migrate when the genesis is exported.
+// The MMR is built from the height index, so the height index comes first
+func (k Keeper) rebuildChainIndexes(ctx sdk.Context) sdk.Error {
+	err := k.rebuildHeightIndex(ctx)
+	if err != nil {
+		return err
+	}
+	err = k.rebuildChainWork(ctx)
+	if err != nil {
+		return err
+	}
+	err = k.rebuildTips(ctx)
+	if err != nil {
+		return err
+	}
+	return k.rebuildMMR(ctx)
+}
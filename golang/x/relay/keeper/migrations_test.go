@@ -0,0 +1,171 @@
+package keeper
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/summa-tx/bitcoin-spv/golang/btcspv"
+	"github.com/summa-tx/relays/golang/x/relay/types"
+)
+
+func (s *KeeperSuite) TestMigrate() {
+	tv := s.Fixtures.ChainTestCases.IsMostRecentCA
+	pre := tv.PreRetargetChain
+
+	// does nothing before genesis is set
+	s.SDKNil(s.Keeper.Migrate(s.Context))
+	s.Equal(storeVersionLegacy, s.Keeper.getStoreVersion(s.Context))
+
+	// new relays start at the current version
+	err := s.Keeper.SetGenesisState(s.Context, tv.Genesis, tv.OldPeriodStart)
+	s.SDKNil(err)
+	s.Equal(currentStoreVersion, s.Keeper.getStoreVersion(s.Context))
+	err = s.Keeper.IngestHeaderChain(s.Context, pre)
+	s.SDKNil(err)
+
+	// rewrite the store the way the legacy keeper did
+	store := s.Keeper.getHeaderStore(s.Context)
+	for _, header := range append([]types.BitcoinHeader{tv.Genesis}, pre...) {
+		store.Set(header.Hash[:], s.Keeper.cdc.MustMarshalBinaryBare(header))
+	}
+	s.Keeper.getChainStore(s.Context).Delete([]byte(types.StoreVersionStorage))
+	s.Equal(storeVersionLegacy, s.Keeper.getStoreVersion(s.Context))
+
+	s.SDKNil(s.Keeper.Migrate(s.Context))
+	s.Equal(currentStoreVersion, s.Keeper.getStoreVersion(s.Context))
+	for _, header := range append([]types.BitcoinHeader{tv.Genesis, tv.OldPeriodStart}, pre...) {
+		s.True(isCompactHeader(store.Get(header.Hash[:])))
+		stored, err := s.Keeper.GetHeader(s.Context, header.Hash)
+		s.SDKNil(err)
+		s.Equal(header, stored)
+	}
+
+	// the epoch difficulty is untouched
+	s.Equal(btcspv.ExtractDifficulty(tv.Genesis.Raw), s.Keeper.getCurrentEpochDifficulty(s.Context))
+}
+
+func (s *KeeperSuite) TestMigrateRequests() {
+	tv := s.Fixtures.ChainTestCases.IsMostRecentCA
+
+	err := s.Keeper.SetGenesisState(s.Context, tv.Genesis, tv.OldPeriodStart)
+	s.SDKNil(err)
+	err = s.Keeper.setRequest(s.Context, nil, []byte{0}, []byte{1}, 5, 2, types.Local, types.HexBytes{7}, types.RequestOptions{})
+	s.SDKNil(err)
+	expected, err := s.Keeper.getRequest(s.Context, types.RequestID{})
+	s.SDKNil(err)
+
+	// requests are stored in binary
+	id := types.RequestID{}
+	store := s.Keeper.getRequestStore(s.Context)
+	s.False(json.Valid(store.Get(id[:])))
+
+	// rewrite the request the way the legacy keeper did
+	legacy, jsonErr := json.Marshal(expected)
+	s.Nil(jsonErr)
+	store.Set(id[:], legacy)
+	s.Keeper.setStoreVersion(s.Context, storeVersionCompactHeaders)
+
+	s.SDKNil(s.Keeper.Migrate(s.Context))
+	s.Equal(currentStoreVersion, s.Keeper.getStoreVersion(s.Context))
+	request, err := s.Keeper.getRequest(s.Context, types.RequestID{})
+	s.SDKNil(err)
+	s.Equal(expected, request)
+
+	// the ID counter is untouched
+	nextID, err := s.Keeper.getNextID(s.Context)
+	s.SDKNil(err)
+	s.Equal(types.RequestID{0, 0, 0, 0, 0, 0, 0, 1}, nextID)
+}
+
+func (s *KeeperSuite) TestMigrateParams() {
+	tv := s.Fixtures.ChainTestCases.IsMostRecentCA
+
+	err := s.Keeper.SetGenesisState(s.Context, tv.Genesis, tv.OldPeriodStart)
+	s.SDKNil(err)
+
+	// keeps params that are already set
+	params := types.DefaultParams()
+	params.MaxActionLength = 1000
+	s.Keeper.SetParams(s.Context, params)
+	s.Keeper.setStoreVersion(s.Context, storeVersionBinaryRequests)
+	s.SDKNil(s.Keeper.Migrate(s.Context))
+	s.Equal(currentStoreVersion, s.Keeper.getStoreVersion(s.Context))
+	s.Equal(params, s.Keeper.GetParams(s.Context))
+}
+
+func (s *KeeperSuite) TestMigrateChainIndexes() {
+	genesis, main := s.initPruneTest(4)
+	tip := main[len(main)-1]
+
+	// stores written before the indexes were added have none
+	s.Keeper.getTipStore(s.Context).Delete(tip.Hash[:])
+	store := s.Keeper.getMMRStore(s.Context)
+	for _, node := range s.Keeper.getAllMMRNodes(s.Context) {
+		store.Delete(mmrNodeKey(node.Level, node.Index))
+	}
+	s.Keeper.setStoreVersion(s.Context, storeVersionParams)
+
+	s.SDKNil(s.Keeper.Migrate(s.Context))
+	s.Equal(currentStoreVersion, s.Keeper.getStoreVersion(s.Context))
+	s.Equal([]types.Hash256Digest{tip.Hash}, s.Keeper.getTipDigests(s.Context))
+	s.checkMMR(genesis.Height, append([]types.BitcoinHeader{genesis}, main...))
+}
+
+func (s *KeeperSuite) clearPrefixStore(prefix string) {
+	store := s.Keeper.getPrefixStore(s.Context, prefix)
+	iterator := sdk.KVStorePrefixIterator(store, nil)
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+func (s *KeeperSuite) TestMigrateLegacyStore() {
+	genesis, main := s.initPruneTest(4)
+	fork := mineChain(main[1], 1, 601)
+	s.SDKNil(s.Keeper.IngestHeaderChain(s.Context, fork))
+	work := s.Keeper.getAllChainWork(s.Context)
+	s.SDKNil(s.Keeper.setRequest(s.Context, nil, []byte{0}, []byte{1}, 5, 2, types.Local, nil, types.RequestOptions{}))
+	expected, err := s.Keeper.getRequest(s.Context, types.RequestID{})
+	s.SDKNil(err)
+	best := append([]types.BitcoinHeader{genesis}, main...)
+
+	// rewrite the store the way the legacy keeper left it, with amino
+	// headers, JSON requests and none of the later indexes
+	headerStore := s.Keeper.getHeaderStore(s.Context)
+	for _, header := range append(best, fork...) {
+		headerStore.Set(header.Hash[:], s.Keeper.cdc.MustMarshalBinaryBare(header))
+	}
+	legacy, jsonErr := json.Marshal(expected)
+	s.Nil(jsonErr)
+	id := types.RequestID{}
+	s.Keeper.getRequestStore(s.Context).Set(id[:], legacy)
+	for _, prefix := range []string{types.HeightStorePrefix, types.ChainWorkStorePrefix, types.TipStorePrefix, types.MMRStorePrefix} {
+		s.clearPrefixStore(prefix)
+	}
+	chainStore := s.Keeper.getChainStore(s.Context)
+	chainStore.Delete([]byte(types.MMRBaseHeightStorage))
+	chainStore.Delete([]byte(types.StoreVersionStorage))
+
+	s.SDKNil(s.Keeper.Migrate(s.Context))
+	s.Equal(currentStoreVersion, s.Keeper.getStoreVersion(s.Context))
+	for _, header := range best {
+		s.True(s.Keeper.isInBestChain(s.Context, header))
+	}
+	s.False(s.Keeper.isInBestChain(s.Context, fork[0]))
+	s.Equal(len(best), len(s.Keeper.getAllHeightDigests(s.Context)))
+	s.Equal(2, len(s.Keeper.getTipDigests(s.Context)))
+	s.Equal(work, s.Keeper.getAllChainWork(s.Context))
+	s.checkMMR(genesis.Height, best)
+	request, err := s.Keeper.getRequest(s.Context, types.RequestID{})
+	s.SDKNil(err)
+	s.Equal(expected, request)
+
+	// the migrated store exports a valid genesis, which the upgraded chain
+	// imports without rebuilding anything
+	s.checkExportImport()
+}
@@ -0,0 +1,222 @@
+package keeper
+
+import (
+	"encoding/binary"
+
+	"github.com/summa-tx/bitcoin-spv/golang/btcspv"
+	"github.com/summa-tx/relays/golang/x/relay/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// getMMRStore returns the nodes of the best chain's Merkle Mountain Range,
+// keyed by level and BE index
+func (k Keeper) getMMRStore(ctx sdk.Context) sdk.KVStore {
+	return k.getPrefixStore(ctx, types.MMRStorePrefix)
+}
+
+func mmrNodeKey(level uint8, index uint32) []byte {
+	key := make([]byte, 5)
+	key[0] = level
+	binary.BigEndian.PutUint32(key[1:], index)
+	return key
+}
+
+// setMMRNode stores a node of the MMR
+func (k Keeper) setMMRNode(ctx sdk.Context, node types.MMRNode) {
+	store := k.getMMRStore(ctx)
+	store.Set(mmrNodeKey(node.Level, node.Index), node.Digest[:])
+}
+
+// getMMRNode reads a node of the MMR
+func (k Keeper) getMMRNode(ctx sdk.Context, level uint8, index uint32) types.Hash256Digest {
+	store := k.getMMRStore(ctx)
+	// Can only fail if data store is corrupt
+	digest, _ := btcspv.NewHash256Digest(store.Get(mmrNodeKey(level, index)))
+	return digest
+}
+
+// getAllMMRNodes returns every node of the MMR, ordered by level and index
+func (k Keeper) getAllMMRNodes(ctx sdk.Context) []types.MMRNode {
+	store := k.getMMRStore(ctx)
+	iterator := sdk.KVStorePrefixIterator(store, nil)
+	defer iterator.Close()
+
+	nodes := []types.MMRNode{}
+	for ; iterator.Valid(); iterator.Next() {
+		// Can only fail if data store is corrupt
+		digest, _ := btcspv.NewHash256Digest(iterator.Value())
+		nodes = append(nodes, types.MMRNode{
+			Level:  iterator.Key()[0],
+			Index:  binary.BigEndian.Uint32(iterator.Key()[1:]),
+			Digest: digest,
+		})
+	}
+	return nodes
+}
+
+// setMMRBaseHeight sets the height of the first leaf of the MMR
+func (k Keeper) setMMRBaseHeight(ctx sdk.Context, height uint32) {
+	store := k.getChainStore(ctx)
+	store.Set([]byte(types.MMRBaseHeightStorage), heightKey(height))
+}
+
+// getMMRBaseHeight returns the height of the first leaf of the MMR. It stays
+// put when the relay genesis is raised, so that pruned blocks can still be
+// proven
+func (k Keeper) getMMRBaseHeight(ctx sdk.Context) uint32 {
+	store := k.getChainStore(ctx)
+	return binary.BigEndian.Uint32(store.Get([]byte(types.MMRBaseHeightStorage)))
+}
+
+// appendMMRLeaf adds a leaf to an MMR with count leaves, and the parents it
+// completes
+func (k Keeper) appendMMRLeaf(ctx sdk.Context, count uint32, digestLE types.Hash256Digest) {
+	node := types.MMRNode{Level: 0, Index: count, Digest: digestLE}
+	k.setMMRNode(ctx, node)
+	// A right child completes its parent
+	for node.Index&1 == 1 {
+		left := k.getMMRNode(ctx, node.Level, node.Index-1)
+		node = types.MMRNode{
+			Level:  node.Level + 1,
+			Index:  node.Index >> 1,
+			Digest: types.MMRParent(left, node.Digest),
+		}
+		k.setMMRNode(ctx, node)
+	}
+}
+
+// truncateMMR shrinks an MMR from count leaves to newCount leaves, deleting
+// every node that commits to a removed leaf
+func (k Keeper) truncateMMR(ctx sdk.Context, count, newCount uint32) {
+	store := k.getMMRStore(ctx)
+	for level := uint8(0); uint64(1)<<level <= uint64(count); level++ {
+		for index := newCount >> level; uint64(index+1)<<level <= uint64(count); index++ {
+			store.Delete(mmrNodeKey(level, index))
+		}
+	}
+}
+
+// updateMMR moves the MMR from prevBest to newBest after the height index
+// has been rewritten. Leaves above the ancestor are replaced with the new
+// best chain
+func (k Keeper) updateMMR(ctx sdk.Context, ancestor, prevBest, newBest types.BitcoinHeader) {
+	base := k.getMMRBaseHeight(ctx)
+	count := ancestor.Height - base + 1
+	k.truncateMMR(ctx, prevBest.Height-base+1, count)
+	for height := ancestor.Height + 1; height <= newBest.Height; height++ {
+		// The height index has just been rewritten up to newBest
+		digest, _ := k.GetDigestByHeight(ctx, height)
+		k.appendMMRLeaf(ctx, count, digest)
+		count++
+	}
+}
+
+// rebuildMMR builds the MMR from the height index, starting at the relay
+// genesis
+func (k Keeper) rebuildMMR(ctx sdk.Context) sdk.Error {
+	store := k.getMMRStore(ctx)
+	for _, node := range k.getAllMMRNodes(ctx) {
+		store.Delete(mmrNodeKey(node.Level, node.Index))
+	}
+
+	genesisDigest, err := k.GetRelayGenesis(ctx)
+	if err != nil {
+		return err
+	}
+	genesis, err := k.GetHeader(ctx, genesisDigest)
+	if err != nil {
+		return err
+	}
+	bestDigest, err := k.GetBestKnownDigest(ctx)
+	if err != nil {
+		return err
+	}
+	best, err := k.GetHeader(ctx, bestDigest)
+	if err != nil {
+		return err
+	}
+	k.setMMRBaseHeight(ctx, genesis.Height)
+
+	for height := genesis.Height; height <= best.Height; height++ {
+		digest, err := k.GetDigestByHeight(ctx, height)
+		if err != nil {
+			return err
+		}
+		k.appendMMRLeaf(ctx, height-genesis.Height, digest)
+	}
+	return nil
+}
+
+// getMMRLeafCount returns the number of leaves in the MMR, one for each best
+// chain block from the base height up to the best known digest
+func (k Keeper) getMMRLeafCount(ctx sdk.Context) (uint32, sdk.Error) {
+	bestDigest, err := k.GetBestKnownDigest(ctx)
+	if err != nil {
+		return 0, err
+	}
+	best, err := k.GetHeader(ctx, bestDigest)
+	if err != nil {
+		return 0, err
+	}
+	return best.Height - k.getMMRBaseHeight(ctx) + 1, nil
+}
+
+// getMMRPeaks returns the digests of the peaks of an MMR with count leaves,
+// from left to right
+func (k Keeper) getMMRPeaks(ctx sdk.Context, count uint32) []types.Hash256Digest {
+	peaks := []types.Hash256Digest{}
+	for _, peak := range types.MMRPeakNodes(count) {
+		peaks = append(peaks, k.getMMRNode(ctx, peak.Level, peak.Index))
+	}
+	return peaks
+}
+
+// GetMMRRoot returns the root of the Merkle Mountain Range over the best
+// chain
+func (k Keeper) GetMMRRoot(ctx sdk.Context) (types.MMRRoot, sdk.Error) {
+	count, err := k.getMMRLeafCount(ctx)
+	if err != nil {
+		return types.MMRRoot{}, err
+	}
+	base := k.getMMRBaseHeight(ctx)
+	return types.MMRRoot{
+		Root:       types.BagMMRPeaks(base, count, k.getMMRPeaks(ctx, count)),
+		LeafCount:  count,
+		BaseHeight: base,
+	}, nil
+}
+
+// GetMMRProof returns a proof that the best chain block at a height is in
+// the Merkle Mountain Range. Blocks below the relay genesis can be proven if
+// they were on the best chain before the history was pruned
+func (k Keeper) GetMMRProof(ctx sdk.Context, height uint32) (types.MMRProof, sdk.Error) {
+	count, err := k.getMMRLeafCount(ctx)
+	if err != nil {
+		return types.MMRProof{}, err
+	}
+	base := k.getMMRBaseHeight(ctx)
+	if height < base || height-base >= count {
+		return types.MMRProof{}, types.ErrUnknownHeight(types.DefaultCodespace, height)
+	}
+
+	index := height - base
+	proof := types.MMRProof{
+		Height:    height,
+		LeafIndex: index,
+		LeafCount: count,
+		Leaf:      k.getMMRNode(ctx, 0, index),
+		Siblings:  []types.Hash256Digest{},
+		Peaks:     k.getMMRPeaks(ctx, count),
+	}
+
+	// Climb until the sibling would commit to leaves past the end
+	for level := uint8(0); ; level++ {
+		sibling := (index >> level) ^ 1
+		if uint64(sibling+1)<<level > uint64(count) {
+			break
+		}
+		proof.Siblings = append(proof.Siblings, k.getMMRNode(ctx, level, sibling))
+	}
+	return proof, nil
+}
@@ -0,0 +1,117 @@
+package keeper
+
+import (
+	"github.com/summa-tx/relays/golang/x/relay/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// mmrRoot computes the root of an MMR over leaves starting at base without
+// the store
+func mmrRoot(base uint32, leaves []types.Hash256Digest) types.Hash256Digest {
+	peaks := []types.Hash256Digest{}
+	for _, peak := range types.MMRPeakNodes(uint32(len(leaves))) {
+		first := int(peak.Index) << peak.Level
+		level := append([]types.Hash256Digest{}, leaves[first:first+1<<peak.Level]...)
+		for len(level) > 1 {
+			next := []types.Hash256Digest{}
+			for i := 0; i < len(level); i += 2 {
+				next = append(next, types.MMRParent(level[i], level[i+1]))
+			}
+			level = next
+		}
+		peaks = append(peaks, level[0])
+	}
+	return types.BagMMRPeaks(base, uint32(len(leaves)), peaks)
+}
+
+// checkMMR checks the MMR root against the best chain, and that every
+// height from the base up to best can be proven
+func (s *KeeperSuite) checkMMR(base uint32, chain []types.BitcoinHeader) {
+	leaves := []types.Hash256Digest{}
+	for _, header := range chain {
+		leaves = append(leaves, header.Hash)
+	}
+
+	root, err := s.Keeper.GetMMRRoot(s.Context)
+	s.SDKNil(err)
+	s.Equal(uint32(len(chain)), root.LeafCount)
+	s.Equal(base, root.BaseHeight)
+	s.Equal(mmrRoot(base, leaves), root.Root)
+
+	for i, header := range chain {
+		proof, err := s.Keeper.GetMMRProof(s.Context, base+uint32(i))
+		s.SDKNil(err)
+		s.Equal(header.Hash, proof.Leaf)
+		s.True(proof.Verify(root))
+	}
+}
+
+func (s *KeeperSuite) TestMMR() {
+	// errors before the relay is initialized
+	_, err := s.Keeper.GetMMRRoot(s.Context)
+	s.Equal(sdk.CodeType(types.BadHash256Digest), err.Code())
+
+	genesis, main := s.initPruneTest(10)
+	s.checkMMR(genesis.Height, append([]types.BitcoinHeader{genesis}, main...))
+
+	// errors outside the range
+	_, err = s.Keeper.GetMMRProof(s.Context, genesis.Height+11)
+	s.Equal(sdk.CodeType(types.UnknownHeight), err.Code())
+
+	// a proof does not verify against another root, or with another leaf
+	root, err := s.Keeper.GetMMRRoot(s.Context)
+	s.SDKNil(err)
+	proof, err := s.Keeper.GetMMRProof(s.Context, 3)
+	s.SDKNil(err)
+	other := root
+	other.Root = main[0].Hash
+	s.False(proof.Verify(other))
+	proof.Leaf = main[0].Hash
+	s.False(proof.Verify(root))
+
+	// leaves above the LCA are replaced on a reorg
+	s.Keeper.AutoAdvance = false
+	fork := mineChain(main[5], 6, 300)
+	for i := 0; i < len(fork); i += 2 {
+		err = s.Keeper.IngestHeaderChain(s.Context, fork[i:i+2])
+		s.SDKNil(err)
+	}
+	err = s.Keeper.MarkNewHeaviest(s.Context, main[5].Hash, main[9].Raw, fork[5].Raw, 10)
+	s.SDKNil(err)
+	chain := append([]types.BitcoinHeader{genesis}, main[:6]...)
+	chain = append(chain, fork...)
+	s.checkMMR(genesis.Height, chain)
+
+	// rebuilding gives the same nodes
+	nodes := s.Keeper.getAllMMRNodes(s.Context)
+	s.SDKNil(s.Keeper.rebuildMMR(s.Context))
+	s.Equal(nodes, s.Keeper.getAllMMRNodes(s.Context))
+
+	// pruned blocks can still be proven
+	s.Keeper.AutoAdvance = true
+	extra := mineChain(fork[5], 6, 300)
+	for i := 0; i < len(extra); i += 2 {
+		err = s.Keeper.IngestHeaderChain(s.Context, extra[i:i+2])
+		s.SDKNil(err)
+	}
+	chain = append(chain, extra...)
+	best := extra[5]
+	s.SDKNil(s.Keeper.pruneHistory(s.Context, genesis, best, 4, 12))
+	s.False(s.Keeper.HasHeader(s.Context, main[0].Hash))
+	s.checkMMR(genesis.Height, chain)
+}
+
+func (s *KeeperSuite) TestExportImportMMR() {
+	genesis, main := s.initPruneTest(6)
+	exported, err := s.Keeper.ExportChainState(s.Context)
+	s.SDKNil(err)
+	s.Equal(genesis.Height, exported.MMRBaseHeight)
+
+	// snapshots without MMR nodes are rebuilt from the height index
+	exported.MMRNodes = nil
+	s.InitTestContext(true, false)
+	err = s.Keeper.ImportChainState(s.Context, exported)
+	s.SDKNil(err)
+	s.checkMMR(genesis.Height, append([]types.BitcoinHeader{genesis}, main...))
+}
@@ -0,0 +1,237 @@
+package keeper
+
+import (
+	"encoding/binary"
+
+	"github.com/summa-tx/bitcoin-spv/golang/btcspv"
+	"github.com/summa-tx/relays/golang/x/relay/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func (k Keeper) emitOrphanChain(ctx sdk.Context, anchor types.Hash256Digest, headers []types.BitcoinHeader) {
+	ctx.EventManager().EmitEvent(types.NewOrphanChainEvent(anchor, headers[0], headers[len(headers)-1]))
+}
+
+// getOrphanStore returns the orphan pool. Keys are the anchor digest followed
+// by the digest of the chain's first header, so that two chains on the same
+// anchor do not collide
+func (k Keeper) getOrphanStore(ctx sdk.Context) sdk.KVStore {
+	return k.getPrefixStore(ctx, types.OrphanStorePrefix)
+}
+
+// getOrphanExpiryStore returns the orphan pool's expiry index. Keys are the
+// BE expiry followed by the orphan store key, and values are the signer. It
+// is small enough to scan when a chain is added or ingested
+func (k Keeper) getOrphanExpiryStore(ctx sdk.Context) sdk.KVStore {
+	return k.getPrefixStore(ctx, types.OrphanExpiryStorePrefix)
+}
+
+// orphanEntry is an entry of the orphan pool's expiry index
+type orphanEntry struct {
+	expiry int64
+	anchor types.Hash256Digest
+	key    []byte
+	signer sdk.AccAddress
+}
+
+func orphanKey(anchor, first types.Hash256Digest) []byte {
+	return append(anchor[:], first[:]...)
+}
+
+func orphanExpiryKey(expiry int64, key []byte) []byte {
+	buf := make([]byte, 8, 8+len(key))
+	binary.BigEndian.PutUint64(buf, uint64(expiry))
+	return append(buf, key...)
+}
+
+// setOrphanChain adds a chain to the orphan pool
+func (k Keeper) setOrphanChain(ctx sdk.Context, orphan types.OrphanChain) {
+	key := orphanKey(orphan.Anchor(), btcspv.Hash256(orphan.Headers[0][:]))
+	k.getOrphanStore(ctx).Set(key, k.cdc.MustMarshalBinaryBare(orphan))
+	k.getOrphanExpiryStore(ctx).Set(orphanExpiryKey(orphan.Expiry, key), orphan.Signer)
+}
+
+// getOrphanChain reads a chain from the orphan pool
+func (k Keeper) getOrphanChain(ctx sdk.Context, key []byte) types.OrphanChain {
+	var orphan types.OrphanChain
+	k.cdc.MustUnmarshalBinaryBare(k.getOrphanStore(ctx).Get(key), &orphan)
+	return orphan
+}
+
+// deleteOrphanEntry removes a chain from the orphan pool
+func (k Keeper) deleteOrphanEntry(ctx sdk.Context, entry orphanEntry) {
+	k.getOrphanStore(ctx).Delete(entry.key)
+	k.getOrphanExpiryStore(ctx).Delete(orphanExpiryKey(entry.expiry, entry.key))
+}
+
+// getOrphanEntries returns every entry of the orphan pool, ordered from the
+// soonest to expire
+func (k Keeper) getOrphanEntries(ctx sdk.Context) []orphanEntry {
+	store := k.getOrphanExpiryStore(ctx)
+	iterator := sdk.KVStorePrefixIterator(store, nil)
+	defer iterator.Close()
+
+	entries := []orphanEntry{}
+	for ; iterator.Valid(); iterator.Next() {
+		key := iterator.Key()
+		// Can only fail if data store is corrupt
+		anchor, _ := btcspv.NewHash256Digest(key[8:40])
+		entries = append(entries, orphanEntry{
+			expiry: int64(binary.BigEndian.Uint64(key[:8])),
+			anchor: anchor,
+			key:    append([]byte{}, key[8:]...),
+			signer: iterator.Value(),
+		})
+	}
+	return entries
+}
+
+// getAllOrphanChains returns every chain in the orphan pool, ordered from
+// the soonest to expire
+func (k Keeper) getAllOrphanChains(ctx sdk.Context) []types.OrphanChain {
+	orphans := []types.OrphanChain{}
+	for _, entry := range k.getOrphanEntries(ctx) {
+		orphans = append(orphans, k.getOrphanChain(ctx, entry.key))
+	}
+	return orphans
+}
+
+// validateOrphanChain checks what can be checked of a chain without its
+// anchor: that the headers are valid, link to each other, have consecutive
+// heights, and start above the relay genesis
+func (k Keeper) validateOrphanChain(ctx sdk.Context, headers []types.BitcoinHeader) sdk.Error {
+	genesisDigest, err := k.GetRelayGenesis(ctx)
+	if err != nil {
+		return err
+	}
+	genesis, err := k.GetHeader(ctx, genesisDigest)
+	if err != nil {
+		return err
+	}
+	if headers[0].Height <= genesis.Height+1 {
+		return types.ErrOrphanBelowGenesis(types.DefaultCodespace, headers[0].Height, genesis.Height)
+	}
+
+	raw := make([]byte, 0, 80*len(headers))
+	for i, header := range headers {
+		_, validErr := header.Validate()
+		if validErr != nil {
+			return types.FromBTCSPVError(types.DefaultCodespace, validErr)
+		}
+		if i != 0 && headers[i-1].Height != header.Height-1 {
+			return types.ErrHeightMismatch(types.DefaultCodespace, headers[i-1].Hash, header.Hash)
+		}
+		raw = append(raw, header.Raw[:]...)
+	}
+
+	_, validErr := btcspv.ValidateHeaderChain(raw)
+	if validErr != nil {
+		return types.FromBTCSPVError(types.DefaultCodespace, validErr)
+	}
+	return nil
+}
+
+// AddOrphanChain holds a chain whose anchor is unknown until the anchor is
+// ingested. A chain that is already pooled is ignored. If the pool is full,
+// the chain closest to expiry is dropped to make room. It returns the
+// unknown anchor error if the pool is disabled or the relay is not
+// initialized
+func (k Keeper) AddOrphanChain(ctx sdk.Context, signer sdk.AccAddress, headers []types.BitcoinHeader) sdk.Error {
+	anchor := headers[0].PrevHash
+	params := k.GetParams(ctx)
+	if params.MaxOrphanChains == 0 || !k.hasRelayGenesis(ctx) {
+		return types.ErrUnknownBlock(types.DefaultCodespace, "anchor", anchor)
+	}
+
+	err := k.validateOrphanChain(ctx, headers)
+	if err != nil {
+		return err
+	}
+
+	key := orphanKey(anchor, headers[0].Hash)
+	if k.getOrphanStore(ctx).Has(key) {
+		return nil
+	}
+
+	entries := k.getOrphanEntries(ctx)
+	signerCount := uint32(0)
+	for _, entry := range entries {
+		if entry.signer.Equals(signer) {
+			signerCount++
+		}
+	}
+	if signerCount >= params.MaxOrphansPerSigner {
+		return types.ErrOrphanLimit(types.DefaultCodespace, signer, params.MaxOrphansPerSigner)
+	}
+	for i := 0; uint32(len(entries)-i) >= params.MaxOrphanChains; i++ {
+		k.deleteOrphanEntry(ctx, entries[i])
+	}
+
+	expiry := ctx.BlockHeight() + int64(params.OrphanExpiry)
+	k.setOrphanChain(ctx, types.NewOrphanChain(signer, expiry, headers))
+	k.emitOrphanChain(ctx, anchor, headers)
+	return nil
+}
+
+// ConnectOrphans ingests the pooled chains whose anchor is now known, and
+// then any that build on those. It is run from the module's EndBlock, so the
+// transaction that stores an anchor does not pay for its orphans. A chain that
+// fails once its anchor is known is dropped without changing the store
+func (k Keeper) ConnectOrphans(ctx sdk.Context) {
+	entries := k.getOrphanEntries(ctx)
+	for connected := len(entries) != 0; connected; {
+		connected = false
+		remaining := entries[:0]
+		for _, entry := range entries {
+			if !k.HasHeader(ctx, entry.anchor) {
+				remaining = append(remaining, entry)
+				continue
+			}
+
+			chain := k.getOrphanChain(ctx, entry.key).BitcoinHeaders()
+			k.deleteOrphanEntry(ctx, entry)
+			if k.ingestOrphanChain(ctx, chain) {
+				connected = true
+			}
+		}
+		entries = remaining
+	}
+}
+
+// ingestOrphanChain ingests a chain whose anchor has arrived, and advances the
+// best tip if auto advance is on. It returns whether the chain was ingested.
+// The store and events are only updated if it was
+func (k Keeper) ingestOrphanChain(ctx sdk.Context, headers []types.BitcoinHeader) bool {
+	cacheCtx, write := ctx.CacheContext()
+	cacheCtx = cacheCtx.WithEventManager(sdk.NewEventManager())
+	if k.IngestHeaderChain(cacheCtx, headers) != nil {
+		return false
+	}
+	write()
+	ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	return true
+}
+
+// ExpireOrphans drops the pooled chains that expire at or before the
+// current block. It is run from the module's EndBlock
+func (k Keeper) ExpireOrphans(ctx sdk.Context) {
+	store := k.getOrphanExpiryStore(ctx)
+	end := make([]byte, 8)
+	binary.BigEndian.PutUint64(end, uint64(ctx.BlockHeight()+1))
+	iterator := store.Iterator(nil, end)
+
+	var expired []orphanEntry
+	for ; iterator.Valid(); iterator.Next() {
+		key := iterator.Key()
+		expired = append(expired, orphanEntry{
+			expiry: int64(binary.BigEndian.Uint64(key[:8])),
+			key:    append([]byte{}, key[8:]...),
+		})
+	}
+	iterator.Close()
+
+	for _, entry := range expired {
+		k.deleteOrphanEntry(ctx, entry)
+	}
+}
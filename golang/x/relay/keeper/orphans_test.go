@@ -0,0 +1,127 @@
+package keeper
+
+import (
+	"bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/summa-tx/relays/golang/x/relay/types"
+)
+
+func (s *KeeperSuite) TestAddOrphanChain() {
+	signer := getAccAddress()
+	other := sdk.AccAddress(bytes.Repeat([]byte{1}, 20))
+
+	// errors if the relay is not initialized
+	params := types.RegtestParams()
+	extra := mineChain(params.GenesisHeader, 4, 600)
+	err := s.Keeper.AddOrphanChain(s.Context, signer, extra[2:])
+	s.Equal(sdk.CodeType(types.UnknownBlock), err.Code())
+
+	_, main := s.initPruneTest(4)
+	extra = mineChain(main[3], 6, 600)
+
+	// errors if the chain could never connect
+	err = s.Keeper.AddOrphanChain(s.Context, signer, main[:1])
+	s.Equal(sdk.CodeType(types.OrphanBelowGenesis), err.Code())
+
+	// errors if the chain is invalid on its own
+	mismatched := []types.BitcoinHeader{extra[2], extra[4]}
+	err = s.Keeper.AddOrphanChain(s.Context, signer, mismatched)
+	s.Equal(sdk.CodeType(types.HeightMismatch), err.Code())
+	mismatched[1].Height = extra[3].Height
+	err = s.Keeper.AddOrphanChain(s.Context, signer, mismatched)
+	s.Equal(sdk.CodeType(types.BitcoinSPV), err.Code())
+
+	// pools a valid chain once
+	err = s.Keeper.AddOrphanChain(s.Context, signer, extra[2:4])
+	s.SDKNil(err)
+	s.Equal("orphan_chain", s.Context.EventManager().Events()[len(s.Context.EventManager().Events())-1].Type)
+	err = s.Keeper.AddOrphanChain(s.Context, signer, extra[2:4])
+	s.SDKNil(err)
+	s.Equal(1, len(s.Keeper.getAllOrphanChains(s.Context)))
+	s.Equal(extra[2:4], s.Keeper.getAllOrphanChains(s.Context)[0].BitcoinHeaders())
+
+	// limits the chains per signer
+	limits := types.DefaultParams()
+	limits.MaxOrphanChains = 2
+	limits.MaxOrphansPerSigner = 1
+	s.Keeper.SetParams(s.Context, limits)
+	err = s.Keeper.AddOrphanChain(s.Context, signer, extra[3:5])
+	s.Equal(sdk.CodeType(types.OrphanLimit), err.Code())
+
+	// drops the chain closest to expiry when full
+	s.Context = s.Context.WithBlockHeight(s.Context.BlockHeight() + 1)
+	err = s.Keeper.AddOrphanChain(s.Context, other, extra[4:])
+	s.SDKNil(err)
+	err = s.Keeper.AddOrphanChain(s.Context, sdk.AccAddress(bytes.Repeat([]byte{2}, 20)), extra[3:5])
+	s.SDKNil(err)
+	orphans := s.Keeper.getAllOrphanChains(s.Context)
+	s.Equal(2, len(orphans))
+	for _, orphan := range orphans {
+		s.False(orphan.Signer.Equals(signer))
+	}
+
+	// errors if the pool is disabled
+	limits.MaxOrphanChains = 0
+	s.Keeper.SetParams(s.Context, limits)
+	err = s.Keeper.AddOrphanChain(s.Context, signer, extra[2:4])
+	s.Equal(sdk.CodeType(types.UnknownBlock), err.Code())
+}
+
+func (s *KeeperSuite) TestConnectOrphans() {
+	_, main := s.initPruneTest(4)
+	extra := mineChain(main[3], 6, 600)
+	signer := getAccAddress()
+
+	// a chain that only fails once its anchor is known
+	bad := mineChain(extra[1], 1, 601)
+	bad[0].Height++
+
+	s.SDKNil(s.Keeper.AddOrphanChain(s.Context, signer, extra[4:]))
+	s.SDKNil(s.Keeper.AddOrphanChain(s.Context, signer, extra[2:4]))
+	s.SDKNil(s.Keeper.AddOrphanChain(s.Context, signer, bad))
+
+	// nothing connects while the anchor is missing
+	s.Keeper.ConnectOrphans(s.Context)
+	s.Equal(3, len(s.Keeper.getAllOrphanChains(s.Context)))
+
+	// once the anchor is ingested, the pooled chains connect in turn
+	err := s.Keeper.IngestHeaderChain(s.Context, extra[:2])
+	s.SDKNil(err)
+	s.Equal(3, len(s.Keeper.getAllOrphanChains(s.Context)))
+	s.Keeper.ConnectOrphans(s.Context)
+	best, err := s.Keeper.GetBestKnownDigest(s.Context)
+	s.SDKNil(err)
+	s.Equal(extra[5].Hash, best)
+	s.False(s.Keeper.HasHeader(s.Context, bad[0].Hash))
+	s.Equal(0, len(s.Keeper.getAllOrphanChains(s.Context)))
+}
+
+func (s *KeeperSuite) TestExpireOrphans() {
+	_, main := s.initPruneTest(4)
+	extra := mineChain(main[3], 4, 600)
+
+	s.Context = s.Context.WithBlockHeight(10)
+	s.SDKNil(s.Keeper.AddOrphanChain(s.Context, getAccAddress(), extra[2:]))
+	expiry := 10 + int64(types.DefaultOrphanExpiry)
+
+	s.Keeper.ExpireOrphans(s.Context.WithBlockHeight(expiry - 1))
+	s.Equal(1, len(s.Keeper.getAllOrphanChains(s.Context)))
+	s.Keeper.ExpireOrphans(s.Context.WithBlockHeight(expiry))
+	s.Equal(0, len(s.Keeper.getAllOrphanChains(s.Context)))
+}
+
+func (s *KeeperSuite) TestHandleOrphanHeaderChain() {
+	_, main := s.initPruneTest(4)
+	extra := mineChain(main[3], 4, 600)
+	handler := NewHandler(s.Keeper)
+
+	res := handler(s.Context, types.NewMsgIngestHeaderChain(getAccAddress(), extra[2:]))
+	s.True(res.IsOK())
+	s.Equal("orphan_chain", res.Events[len(res.Events)-1].Type)
+
+	res = handler(s.Context, types.NewMsgIngestHeaderChain(getAccAddress(), extra[:2]))
+	s.True(res.IsOK())
+	s.Keeper.ConnectOrphans(s.Context)
+	s.True(s.Keeper.HasHeader(s.Context, extra[3].Hash))
+}
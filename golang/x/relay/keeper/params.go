@@ -0,0 +1,44 @@
+package keeper
+
+import (
+	"github.com/summa-tx/relays/golang/x/relay/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GetParams returns the relay's governance-tunable limits. Param change
+// proposals skip validation, so unusable values are clamped
+func (k Keeper) GetParams(ctx sdk.Context) types.Params {
+	var params types.Params
+	k.paramSpace.GetParamSet(ctx, &params)
+	return params.Clamp()
+}
+
+// ClampParams writes back the clamped values of params that a change
+// proposal set to values the relay can not use, so that the stored params
+// reported by the params module and the `params` query are the ones the
+// keeper reads. It emits a params_clamped event when it changes anything
+func (k Keeper) ClampParams(ctx sdk.Context) {
+	var params types.Params
+	k.paramSpace.GetParamSet(ctx, &params)
+	clamped := params.Clamp()
+	if clamped == params {
+		return
+	}
+	k.SetParams(ctx, clamped)
+	ctx.Logger().Info("clamped unusable relay params")
+	ctx.EventManager().EmitEvent(types.NewParamsClampedEvent(clamped))
+}
+
+// SetParams sets the relay's governance-tunable limits
+func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	k.paramSpace.SetParamSet(ctx, &params)
+}
+
+// lookupLimit replaces an unset lookup limit with the default
+func (k Keeper) lookupLimit(ctx sdk.Context, limit uint32) uint32 {
+	if limit == 0 {
+		return k.GetParams(ctx).DefaultLookupLimit
+	}
+	return limit
+}
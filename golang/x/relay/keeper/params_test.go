@@ -0,0 +1,87 @@
+package keeper
+
+import (
+	"bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/summa-tx/relays/golang/x/relay/types"
+)
+
+func (s *KeeperSuite) TestParams() {
+	s.Equal(types.DefaultParams(), s.Keeper.GetParams(s.Context))
+
+	params := types.DefaultParams()
+	params.DefaultLookupLimit = 5
+	s.Keeper.SetParams(s.Context, params)
+	s.Equal(params, s.Keeper.GetParams(s.Context))
+
+	// unset limits use the default
+	s.Equal(uint32(5), s.Keeper.lookupLimit(s.Context, 0))
+	s.Equal(uint32(7), s.Keeper.lookupLimit(s.Context, 7))
+
+	// param change proposals are not validated, so bad values are clamped
+	s.Keeper.paramSpace.Set(s.Context, types.KeyMaxLookupLimit, uint32(0))
+	s.Keeper.paramSpace.Set(s.Context, types.KeyHistoryRetention, uint32(1))
+	params = s.Keeper.GetParams(s.Context)
+	s.Nil(params.Validate())
+	s.Equal(uint32(1), params.MaxLookupLimit)
+	s.Equal(uint32(1), params.DefaultLookupLimit)
+	s.Equal(uint32(1), params.HistoryRetention)
+}
+
+func (s *KeeperSuite) TestClampParams() {
+	// does nothing when the params are usable
+	s.Keeper.ClampParams(s.Context)
+	s.Equal(0, len(s.Context.EventManager().Events()))
+
+	// writes back the values the keeper reads
+	s.Keeper.paramSpace.Set(s.Context, types.KeyMaxLookupLimit, uint32(0))
+	s.Keeper.ClampParams(s.Context)
+	var stored uint32
+	s.Keeper.paramSpace.Get(s.Context, types.KeyMaxLookupLimit, &stored)
+	s.Equal(uint32(1), stored)
+	var raw types.Params
+	s.Keeper.paramSpace.GetParamSet(s.Context, &raw)
+	s.Equal(s.Keeper.GetParams(s.Context), raw)
+
+	events := s.Context.EventManager().Events()
+	s.Equal(1, len(events))
+	s.Equal(types.EventTypeParamsClamped, events[0].Type)
+}
+
+func (s *KeeperSuite) TestMaxLookupLimit() {
+	tv := s.Fixtures.ChainTestCases.IsMostRecentCA
+
+	err := s.Keeper.SetGenesisState(s.Context, tv.Genesis, tv.OldPeriodStart)
+	s.SDKNil(err)
+
+	params := types.DefaultParams()
+	params.MaxLookupLimit = 9
+	s.Keeper.SetParams(s.Context, params)
+
+	err = s.Keeper.MarkNewHeaviest(s.Context, tv.Genesis.Hash, tv.Genesis.Raw, tv.PreRetargetChain[0].Raw, 10)
+	s.Equal(sdk.CodeType(types.LimitTooHigh), err.Code())
+}
+
+func (s *KeeperSuite) TestValidateRequestLengths() {
+	spends := bytes.Repeat([]byte{0}, 36)
+
+	s.SDKNil(s.Keeper.validateRequestLengths(s.Context, spends, bytes.Repeat([]byte{0}, 50), bytes.Repeat([]byte{0}, 500)))
+	s.SDKNil(s.Keeper.validateRequestLengths(s.Context, []byte{}, []byte{}, nil))
+
+	err := s.Keeper.validateRequestLengths(s.Context, []byte{0}, []byte{}, nil)
+	s.Equal(sdk.CodeType(types.SpendsLength), err.Code())
+	err = s.Keeper.validateRequestLengths(s.Context, spends, bytes.Repeat([]byte{0}, 51), nil)
+	s.Equal(sdk.CodeType(types.PaysLength), err.Code())
+	err = s.Keeper.validateRequestLengths(s.Context, spends, []byte{}, bytes.Repeat([]byte{0}, 501))
+	s.Equal(sdk.CodeType(types.ActionLength), err.Code())
+
+	// the limits follow the params
+	params := types.DefaultParams()
+	params.SpendsLength = 1
+	params.MaxPaysLength = 60
+	s.Keeper.SetParams(s.Context, params)
+	s.SDKNil(s.Keeper.validateRequestLengths(s.Context, []byte{0}, bytes.Repeat([]byte{0}, 60), nil))
+	err = s.Keeper.validateRequestLengths(s.Context, spends, []byte{}, nil)
+	s.Equal(sdk.CodeType(types.SpendsLength), err.Code())
+}
@@ -0,0 +1,45 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/summa-tx/relays/golang/x/relay/types"
+)
+
+// NewProposalHandler returns a handler for relay governance proposals
+func NewProposalHandler(keeper Keeper) govtypes.Handler {
+	return func(ctx sdk.Context, content govtypes.Content) sdk.Error {
+		switch c := content.(type) {
+		case types.CheckpointProposal:
+			return handleCheckpointProposal(ctx, keeper, c)
+		default:
+			errMsg := fmt.Sprintf("Unrecognized relay proposal content type: %T", c)
+			return sdk.ErrUnknownRequest(errMsg)
+		}
+	}
+}
+
+func handleCheckpointProposal(ctx sdk.Context, keeper Keeper, p types.CheckpointProposal) sdk.Error {
+	for _, height := range p.Remove {
+		err := keeper.DeleteCheckpoint(ctx, height)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, checkpoint := range p.Add {
+		// A checkpoint may not contradict the current best chain
+		digest, err := keeper.GetDigestByHeight(ctx, checkpoint.Height)
+		if err == nil && digest != checkpoint.Digest {
+			return types.ErrCheckpointConflict(types.DefaultCodespace, digest, checkpoint.Height, checkpoint.Digest)
+		}
+		keeper.SetCheckpoint(ctx, checkpoint)
+	}
+
+	if p.RaiseGenesis != 0 {
+		return keeper.RaiseGenesis(ctx, p.RaiseGenesis)
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package keeper
+
+import (
+	"github.com/summa-tx/relays/golang/x/relay/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// maxPrunedPerBlock bounds the headers removed in one block, so that a large
+// backlog is pruned over several blocks
+const maxPrunedPerBlock uint32 = 500
+
+// Prune removes fork branches that have fallen ForkPruneDepth blocks behind
+// the best tip, then best chain headers more than HistoryRetention blocks
+// below it. It is run from the module's EndBlock
+func (k Keeper) Prune(ctx sdk.Context) sdk.Error {
+	if !k.hasRelayGenesis(ctx) {
+		return nil
+	}
+
+	params := k.GetParams(ctx)
+	if params.ForkPruneDepth == 0 && params.HistoryRetention == 0 {
+		return nil
+	}
+
+	bestDigest, err := k.GetBestKnownDigest(ctx)
+	if err != nil {
+		return err
+	}
+	best, err := k.GetHeader(ctx, bestDigest)
+	if err != nil {
+		return err
+	}
+	genesisDigest, err := k.GetRelayGenesis(ctx)
+	if err != nil {
+		return err
+	}
+	genesis, err := k.GetHeader(ctx, genesisDigest)
+	if err != nil {
+		return err
+	}
+
+	budget := maxPrunedPerBlock
+	if params.ForkPruneDepth != 0 {
+		budget = k.pruneStaleForks(ctx, genesis, best, params.ForkPruneDepth, budget)
+	}
+	if params.HistoryRetention != 0 && budget != 0 {
+		return k.pruneHistory(ctx, genesis, best, params.HistoryRetention, budget)
+	}
+	return nil
+}
+
+// isBranchBase checks whether a walk down a fork branch has left the branch.
+// That is the case once it reaches the best chain or the relay genesis, or a
+// block that is already pruned
+func (k Keeper) isBranchBase(ctx sdk.Context, digestLE types.Hash256Digest, height, genesisHeight uint32) bool {
+	if height <= genesisHeight || !k.hasLink(ctx, digestLE) {
+		return true
+	}
+	bestDigest, err := k.GetDigestByHeight(ctx, height)
+	return err == nil && bestDigest == digestLE
+}
+
+// pruneStaleForks deletes the branches of fork tips at least depth blocks
+// below the best tip. Blocks shared with a fork that is not stale are kept.
+// It removes at most budget headers and returns the unused budget. A branch
+// that is only partly pruned keeps a tip at its new top, so that the next
+// block resumes it
+func (k Keeper) pruneStaleForks(ctx sdk.Context, genesis, best types.BitcoinHeader, depth, budget uint32) uint32 {
+	var stale, live []types.HeightDigest
+	for _, tip := range k.getTips(ctx) {
+		if tip.Digest == best.Hash {
+			continue
+		}
+		if tip.Height+depth <= best.Height {
+			stale = append(stale, tip)
+		} else {
+			live = append(live, tip)
+		}
+	}
+	if len(stale) == 0 {
+		return budget
+	}
+
+	keep := make(map[types.Hash256Digest]bool)
+	for _, tip := range live {
+		current, height := tip.Digest, tip.Height
+		for !k.isBranchBase(ctx, current, height, genesis.Height) && !keep[current] {
+			keep[current] = true
+			current, height = k.getLink(ctx, current), height-1
+		}
+	}
+
+	for _, tip := range stale {
+		k.deleteTip(ctx, tip.Digest)
+		current, height := tip.Digest, tip.Height
+		for !k.isBranchBase(ctx, current, height, genesis.Height) && !keep[current] {
+			if budget == 0 {
+				k.setTip(ctx, current, height)
+				return 0
+			}
+			parent := k.getLink(ctx, current)
+			k.deleteHeader(ctx, current)
+			budget--
+			current, height = parent, height-1
+		}
+	}
+	return budget
+}
+
+// pruneForksBelow deletes every fork branch that leaves the best chain below
+// height, along with its tip. Their lowest blocks build on blocks that are
+// pruned when the relay genesis moves up to height. The branch bases are all
+// found first, as a pruned branch may share blocks with another
+func (k Keeper) pruneForksBelow(ctx sdk.Context, genesis types.BitcoinHeader, bestDigest types.Hash256Digest, height uint32) {
+	var below []types.HeightDigest
+	for _, tip := range k.getTips(ctx) {
+		if tip.Digest == bestDigest {
+			continue
+		}
+		current, h := tip.Digest, tip.Height
+		for !k.isBranchBase(ctx, current, h, genesis.Height) {
+			current, h = k.getLink(ctx, current), h-1
+		}
+		if h < height {
+			below = append(below, tip)
+		}
+	}
+
+	for _, tip := range below {
+		k.deleteTip(ctx, tip.Digest)
+		current, h := tip.Digest, tip.Height
+		for !k.isBranchBase(ctx, current, h, genesis.Height) {
+			parent := k.getLink(ctx, current)
+			k.deleteHeader(ctx, current)
+			current, h = parent, h-1
+		}
+	}
+}
+
+// minHistoryRetention is the fewest best chain blocks kept below the best
+// tip, whatever the HistoryRetention param. New headers are checked against
+// the median time of the 11 blocks before them, and on testnet against the
+// last block of their retarget period not mined at the minimum difficulty.
+// Both walks fail open at a pruned header
+func (k Keeper) minHistoryRetention(ctx sdk.Context) uint32 {
+	interval := k.GetChainParams(ctx).RetargetInterval
+	if interval < medianTimeSpan {
+		return medianTimeSpan
+	}
+	return interval
+}
+
+// pruneHistory raises the relay genesis to the best chain block retention
+// blocks below the best tip, deleting the headers below it. The retention is
+// raised to the minimum the header checks need. It removes at most budget
+// best chain headers
+func (k Keeper) pruneHistory(ctx sdk.Context, genesis, best types.BitcoinHeader, retention, budget uint32) sdk.Error {
+	if floor := k.minHistoryRetention(ctx); retention < floor {
+		retention = floor
+	}
+	if best.Height <= genesis.Height+retention {
+		return nil
+	}
+	height := best.Height - retention
+	if height-genesis.Height > budget {
+		height = genesis.Height + budget
+	}
+	newGenesis, err := k.GetDigestByHeight(ctx, height)
+	if err != nil {
+		return err
+	}
+	return k.moveGenesis(ctx, genesis, height, newGenesis)
+}
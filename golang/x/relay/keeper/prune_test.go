@@ -0,0 +1,246 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/summa-tx/bitcoin-spv/golang/btcspv"
+	"github.com/summa-tx/relays/golang/x/relay/types"
+)
+
+// mineChain builds n regtest headers on prev, spacing seconds apart. Chains
+// on the same parent need different spacings to differ
+func mineChain(prev types.BitcoinHeader, n int, spacing uint32) []types.BitcoinHeader {
+	headers := make([]types.BitcoinHeader, n)
+	for i := range headers {
+		prev = mineHeader(prev, uint32(btcspv.ExtractTimestamp(prev.Raw))+spacing, 0x207fffff)
+		headers[i] = prev
+	}
+	return headers
+}
+
+// initPruneTest starts a regtest relay with an epoch every 4 blocks, and
+// ingests a best chain of n headers
+func (s *KeeperSuite) initPruneTest(n int) (types.BitcoinHeader, []types.BitcoinHeader) {
+	params := types.RegtestParams()
+	params.RetargetInterval = 4
+	params.AllowMinDifficultyBlocks = false
+	s.Keeper.SetChainParams(s.Context, params)
+	s.Keeper.AutoAdvance = true
+
+	genesis := params.GenesisHeader
+	err := s.Keeper.SetGenesisState(s.Context, genesis, genesis)
+	s.SDKNil(err)
+
+	main := mineChain(genesis, n, 600)
+	for i := 0; i < n; i += 2 {
+		err = s.Keeper.IngestHeaderChain(s.Context, main[i:i+2])
+		s.SDKNil(err)
+	}
+	return genesis, main
+}
+
+func (s *KeeperSuite) TestPrune() {
+	genesis, main := s.initPruneTest(20)
+
+	// does nothing when pruning is disabled
+	params := types.DefaultParams()
+	params.ForkPruneDepth = 0
+	s.Keeper.SetParams(s.Context, params)
+	s.SDKNil(s.Keeper.Prune(s.Context))
+	s.True(s.Keeper.HasHeader(s.Context, genesis.Hash))
+
+	// does nothing with the default params on a short chain
+	s.Keeper.SetParams(s.Context, types.DefaultParams())
+	s.SDKNil(s.Keeper.Prune(s.Context))
+	s.True(s.Keeper.HasHeader(s.Context, genesis.Hash))
+
+	// prunes forks and history together
+	fork := mineChain(main[2], 1, 601)
+	err := s.Keeper.IngestHeaderChain(s.Context, fork)
+	s.SDKNil(err)
+	params = types.DefaultParams()
+	params.MaxLookupLimit = 4
+	params.DefaultLookupLimit = 4
+	params.ForkPruneDepth = 4
+	params.HistoryRetention = 4
+	s.Keeper.SetParams(s.Context, params)
+	s.SDKNil(s.Keeper.Prune(s.Context))
+	s.False(s.Keeper.HasHeader(s.Context, fork[0].Hash))
+	relayGenesis, err := s.Keeper.GetRelayGenesis(s.Context)
+	s.SDKNil(err)
+	s.Equal(main[8].Hash, relayGenesis)
+}
+
+func (s *KeeperSuite) TestPruneStaleForks() {
+	genesis, main := s.initPruneTest(10)
+	best := main[9]
+
+	// forkA leaves the best chain at height 3, and forkB leaves forkA
+	forkA := mineChain(main[2], 3, 601)
+	err := s.Keeper.IngestHeaderChain(s.Context, forkA)
+	s.SDKNil(err)
+	forkB := mineChain(forkA[0], 4, 602)
+	err = s.Keeper.IngestHeaderChain(s.Context, forkB)
+	s.SDKNil(err)
+
+	// nothing is stale
+	s.Equal(uint32(5), s.Keeper.pruneStaleForks(s.Context, genesis, best, 5, 5))
+	s.True(s.Keeper.HasHeader(s.Context, forkA[2].Hash))
+
+	// forkA is stale, but shares its first block with forkB
+	s.Equal(uint32(3), s.Keeper.pruneStaleForks(s.Context, genesis, best, 4, 5))
+	for _, header := range forkA[1:] {
+		s.False(s.Keeper.HasHeader(s.Context, header.Hash))
+		s.False(s.Keeper.hasLink(s.Context, header.Hash))
+		s.True(s.Keeper.getChainWork(s.Context, header.Hash).IsZero())
+	}
+	s.False(s.Keeper.isTip(s.Context, forkA[2].Hash))
+	s.True(s.Keeper.HasHeader(s.Context, forkA[0].Hash))
+	s.True(s.Keeper.isTip(s.Context, forkB[3].Hash))
+
+	// a partly pruned branch keeps a tip at its new top
+	s.Equal(uint32(0), s.Keeper.pruneStaleForks(s.Context, genesis, best, 2, 3))
+	s.False(s.Keeper.HasHeader(s.Context, forkB[1].Hash))
+	s.True(s.Keeper.HasHeader(s.Context, forkB[0].Hash))
+	s.True(s.Keeper.isTip(s.Context, forkB[0].Hash))
+
+	// the next pass finishes the branch, stopping at the best chain
+	s.Equal(uint32(3), s.Keeper.pruneStaleForks(s.Context, genesis, best, 2, 5))
+	s.False(s.Keeper.HasHeader(s.Context, forkB[0].Hash))
+	s.False(s.Keeper.HasHeader(s.Context, forkA[0].Hash))
+	s.True(s.Keeper.HasHeader(s.Context, main[2].Hash))
+	s.Equal([]types.Hash256Digest{best.Hash}, s.Keeper.getTipDigests(s.Context))
+}
+
+func (s *KeeperSuite) TestPruneHistory() {
+	genesis, main := s.initPruneTest(20)
+	best := main[19]
+
+	// does nothing while the chain is within the retention
+	s.SDKNil(s.Keeper.pruneHistory(s.Context, genesis, best, 20, 5))
+	s.True(s.Keeper.HasHeader(s.Context, genesis.Hash))
+
+	// prunes up to the budget
+	s.SDKNil(s.Keeper.pruneHistory(s.Context, genesis, best, 12, 3))
+	relayGenesis, err := s.Keeper.GetRelayGenesis(s.Context)
+	s.SDKNil(err)
+	s.Equal(main[2].Hash, relayGenesis)
+	s.False(s.Keeper.hasLink(s.Context, main[2].Hash))
+	s.True(s.Keeper.HasHeader(s.Context, main[2].Hash))
+	s.False(s.Keeper.HasHeader(s.Context, main[1].Hash))
+	s.False(s.Keeper.HasHeader(s.Context, main[0].Hash))
+	_, err = s.Keeper.GetDigestByHeight(s.Context, 2)
+	s.NotNil(err)
+
+	// the epoch start is kept, without its link
+	s.True(s.Keeper.HasHeader(s.Context, genesis.Hash))
+
+	// the next pass reaches the retention, keeping the epoch start at 4
+	newGenesis, err := s.Keeper.GetHeader(s.Context, relayGenesis)
+	s.SDKNil(err)
+	s.SDKNil(s.Keeper.pruneHistory(s.Context, newGenesis, best, 12, 10))
+	relayGenesis, err = s.Keeper.GetRelayGenesis(s.Context)
+	s.SDKNil(err)
+	s.Equal(main[7].Hash, relayGenesis)
+	s.True(s.Keeper.HasHeader(s.Context, main[3].Hash))
+	s.False(s.Keeper.hasLink(s.Context, main[3].Hash))
+	s.False(s.Keeper.HasHeader(s.Context, main[4].Hash))
+
+	// the last reorg LCA is moved up with the genesis, and a retention
+	// below the minimum keeps 11 blocks under the tip
+	s.Keeper.setLastReorgLCA(s.Context, main[7].Hash)
+	newGenesis, err = s.Keeper.GetHeader(s.Context, relayGenesis)
+	s.SDKNil(err)
+	s.SDKNil(s.Keeper.pruneHistory(s.Context, newGenesis, best, 4, 10))
+	relayGenesis, err = s.Keeper.GetRelayGenesis(s.Context)
+	s.SDKNil(err)
+	s.Equal(main[8].Hash, relayGenesis)
+	lca, err := s.Keeper.GetLastReorgLCA(s.Context)
+	s.SDKNil(err)
+	s.Equal(main[8].Hash, lca)
+
+	// the pruned relay still exports
+	s.checkExportImport()
+}
+
+func (s *KeeperSuite) TestPruneHistoryMinDifficulty() {
+	// testnet rules with an epoch every 8 blocks, so that the 11 blocks
+	// kept below the tip span a retarget
+	params := types.RegtestParams()
+	params.RetargetInterval = 8
+	s.Keeper.SetChainParams(s.Context, params)
+	s.Keeper.AutoAdvance = true
+	const minBits = 0x207fffff
+	const realBits = 0x2000ffff
+
+	start := params.GenesisHeader
+	start.Height = 7
+	genesis := mineHeader(start, 1000000, realBits)
+	s.SDKNil(s.Keeper.SetGenesisState(s.Context, genesis, genesis))
+
+	// real blocks up to height 17, then min difficulty blocks up to 20
+	var main []types.BitcoinHeader
+	prev := genesis
+	for i := 0; i < 12; i++ {
+		timestamp := uint32(btcspv.ExtractTimestamp(prev.Raw))
+		if i < 9 {
+			prev = mineHeader(prev, timestamp+600, realBits)
+		} else {
+			prev = mineHeader(prev, timestamp+1201, minBits)
+		}
+		main = append(main, prev)
+	}
+	s.SDKNil(s.Keeper.IngestHeaderChain(s.Context, main[:7]))
+	s.SDKNil(s.Keeper.IngestDifficultyChange(s.Context, genesis.Hash, main[7:]))
+	best := main[11]
+	bestDigest, err := s.Keeper.GetBestKnownDigest(s.Context)
+	s.SDKNil(err)
+	s.Equal(best.Hash, bestDigest)
+
+	// a retention of 1 still keeps the blocks back to the last real one
+	s.SDKNil(s.Keeper.pruneHistory(s.Context, genesis, best, 1, 100))
+	relayGenesis, err := s.Keeper.GetRelayGenesis(s.Context)
+	s.SDKNil(err)
+	s.Equal(main[0].Hash, relayGenesis)
+	s.True(s.Keeper.HasHeader(s.Context, main[8].Hash))
+
+	// so a block within 20 minutes must still use the real target
+	timestamp := uint32(btcspv.ExtractTimestamp(best.Raw)) + 600
+	easy := mineHeader(best, timestamp, minBits)
+	err = s.Keeper.IngestHeaderChain(s.Context, []types.BitcoinHeader{easy})
+	s.Equal(sdk.CodeType(types.UnexpectedRetarget), err.Code())
+	s.SDKNil(s.Keeper.IngestHeaderChain(s.Context, []types.BitcoinHeader{mineHeader(best, timestamp, realBits)}))
+}
+
+func (s *KeeperSuite) TestPruneHistoryForks() {
+	genesis, main := s.initPruneTest(20)
+	best := main[19]
+
+	// forkA leaves the best chain below the new genesis, and forkB leaves
+	// forkA. forkC leaves the new genesis itself
+	forkA := mineChain(main[0], 4, 601)
+	s.SDKNil(s.Keeper.IngestHeaderChain(s.Context, forkA))
+	forkB := mineChain(forkA[2], 2, 602)
+	s.SDKNil(s.Keeper.IngestHeaderChain(s.Context, forkB))
+	forkC := mineChain(main[2], 2, 601)
+	s.SDKNil(s.Keeper.IngestHeaderChain(s.Context, forkC))
+
+	s.SDKNil(s.Keeper.pruneHistory(s.Context, genesis, best, 12, 3))
+	relayGenesis, err := s.Keeper.GetRelayGenesis(s.Context)
+	s.SDKNil(err)
+	s.Equal(main[2].Hash, relayGenesis)
+
+	// branches based below the genesis are deleted with their tips
+	for _, header := range append(forkA, forkB...) {
+		s.False(s.Keeper.HasHeader(s.Context, header.Hash))
+		s.False(s.Keeper.hasLink(s.Context, header.Hash))
+		s.True(s.Keeper.getChainWork(s.Context, header.Hash).IsZero())
+		s.False(s.Keeper.isTip(s.Context, header.Hash))
+	}
+	for _, header := range forkC {
+		s.True(s.Keeper.HasHeader(s.Context, header.Hash))
+	}
+	s.True(s.Keeper.isTip(s.Context, forkC[1].Hash))
+	s.Equal(2, len(s.Keeper.getTipDigests(s.Context)))
+
+	s.checkExportImport()
+}
@@ -42,10 +42,38 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return queryIsMostRecentCommonAncestor(ctx, req, keeper)
 		case types.QueryGetRequest:
 			return queryGetRequest(ctx, req, keeper)
+		case types.QueryGetProvenTx:
+			return queryGetProvenTx(ctx, req, keeper)
 		case types.QueryCheckRequests:
 			return queryCheckRequests(ctx, req, keeper)
 		case types.QueryCheckProof:
 			return queryCheckProof(ctx, req, keeper)
+		case types.QueryGetHeader:
+			return queryGetHeader(ctx, req, keeper)
+		case types.QueryGetHeaderByHeight:
+			return queryGetHeaderByHeight(ctx, req, keeper)
+		case types.QueryGetChainWork:
+			return queryGetChainWork(ctx, req, keeper)
+		case types.QueryGetCurrentEpochDifficulty:
+			return queryGetCurrentEpochDifficulty(ctx, req, keeper)
+		case types.QueryGetPrevEpochDifficulty:
+			return queryGetPrevEpochDifficulty(ctx, req, keeper)
+		case types.QueryFindHeight:
+			return queryFindHeight(ctx, req, keeper)
+		case types.QueryGetChainParams:
+			return queryGetChainParams(ctx, req, keeper)
+		case types.QueryGetTips:
+			return queryGetTips(ctx, req, keeper)
+		case types.QueryGetMMRRoot:
+			return queryGetMMRRoot(ctx, req, keeper)
+		case types.QueryGetMMRProof:
+			return queryGetMMRProof(ctx, req, keeper)
+		case types.QueryGetHeaders:
+			return queryGetHeaders(ctx, req, keeper)
+		case types.QueryLocateHeaders:
+			return queryLocateHeaders(ctx, req, keeper)
+		case types.QueryParams:
+			return queryParams(ctx, req, keeper)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown relay query endpoint")
 		}
@@ -60,10 +88,7 @@ func queryIsAncestor(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res
 		return nil, sdk.ErrInternal(fmt.Sprintf("failed to parse params: %s", unmarshallErr))
 	}
 
-	limit := params.Limit
-	if limit == 0 {
-		limit = types.DefaultLookupLimit
-	}
+	limit := keeper.lookupLimit(ctx, params.Limit)
 
 	// This calls the keeper with the parsed arguments, and gets an answer
 	result := keeper.IsAncestor(ctx, params.DigestLE, params.ProspectiveAncestor, limit)
@@ -178,10 +203,7 @@ func queryHeaviestFromAncestor(ctx sdk.Context, req abci.RequestQuery, keeper Ke
 		return nil, sdk.ErrInternal(fmt.Sprintf("failed to parse params: %s", unmarshallErr))
 	}
 
-	limit := params.Limit
-	if limit == 0 {
-		limit = types.DefaultLookupLimit
-	}
+	limit := keeper.lookupLimit(ctx, params.Limit)
 
 	// This calls the keeper with the parsed arguments, and gets an answer
 	result, err := keeper.HeaviestFromAncestor(ctx, params.Ancestor, params.CurrentBest, params.NewBest, limit)
@@ -211,10 +233,7 @@ func queryIsMostRecentCommonAncestor(ctx sdk.Context, req abci.RequestQuery, kee
 		return nil, sdk.ErrInternal(fmt.Sprintf("failed to parse params: %s", unmarshallErr))
 	}
 
-	limit := params.Limit
-	if limit == 0 {
-		limit = types.DefaultLookupLimit
-	}
+	limit := keeper.lookupLimit(ctx, params.Limit)
 
 	// This calls the keeper with the parsed arguments, and gets an answer
 	result := keeper.IsMostRecentCommonAncestor(ctx, params.Ancestor, params.Left, params.Right, limit)
@@ -251,6 +270,7 @@ func queryGetRequest(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res
 	response := types.QueryResGetRequest{
 		Params: params,
 		Res:    result,
+		Fills:  keeper.getFillsByRequest(ctx, params.ID),
 	}
 
 	// And we serialize that response as JSON
@@ -261,6 +281,31 @@ func queryGetRequest(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res
 	return res, nil
 }
 
+func queryGetProvenTx(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params types.QueryParamsGetProvenTx
+
+	unmarshallErr := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if unmarshallErr != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to parse params: %s", unmarshallErr))
+	}
+
+	result, err := keeper.GetProvenTx(ctx, params.TxID)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	response := types.QueryResGetProvenTx{
+		Params: params,
+		Res:    result,
+	}
+
+	res, marshalErr := codec.MarshalJSONIndent(keeper.cdc, response)
+	if marshalErr != nil {
+		return []byte{}, types.ErrMarshalJSON(types.DefaultCodespace)
+	}
+	return res, nil
+}
+
 func queryCheckRequests(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
 	var params types.QueryParamsCheckRequests
 	var errMsg string
@@ -324,3 +369,318 @@ func queryCheckProof(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res
 	}
 	return res, nil
 }
+
+func queryGetHeader(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params types.QueryParamsGetHeader
+
+	unmarshallErr := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if unmarshallErr != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to parse params: %s", unmarshallErr))
+	}
+
+	// This calls the keeper with the parsed arguments, and gets an answer
+	result, err := keeper.GetHeader(ctx, params.DigestLE)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	// Now we format the answer as a response
+	response := types.QueryResGetHeader{
+		Params: params,
+		Res:    result,
+	}
+
+	// And we serialize that response as JSON
+	res, marshalErr := codec.MarshalJSONIndent(keeper.cdc, response)
+	if marshalErr != nil {
+		return []byte{}, types.ErrMarshalJSON(types.DefaultCodespace)
+	}
+	return res, nil
+}
+
+func queryGetHeaderByHeight(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params types.QueryParamsGetHeaderByHeight
+
+	unmarshallErr := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if unmarshallErr != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to parse params: %s", unmarshallErr))
+	}
+
+	// This calls the keeper with the parsed arguments, and gets an answer
+	result, err := keeper.GetHeaderByHeight(ctx, params.Height)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	// Now we format the answer as a response
+	response := types.QueryResGetHeaderByHeight{
+		Params: params,
+		Res:    result,
+	}
+
+	// And we serialize that response as JSON
+	res, marshalErr := codec.MarshalJSONIndent(keeper.cdc, response)
+	if marshalErr != nil {
+		return []byte{}, types.ErrMarshalJSON(types.DefaultCodespace)
+	}
+	return res, nil
+}
+
+func queryGetChainWork(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params types.QueryParamsGetChainWork
+
+	unmarshallErr := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if unmarshallErr != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to parse params: %s", unmarshallErr))
+	}
+
+	// This calls the keeper with the parsed arguments, and gets an answer
+	result, err := keeper.GetChainWork(ctx, params.DigestLE)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	// Now we format the answer as a response
+	response := types.QueryResGetChainWork{
+		Params: params,
+		Res:    result,
+	}
+
+	// And we serialize that response as JSON
+	res, marshalErr := codec.MarshalJSONIndent(keeper.cdc, response)
+	if marshalErr != nil {
+		return []byte{}, types.ErrMarshalJSON(types.DefaultCodespace)
+	}
+	return res, nil
+}
+
+func queryGetCurrentEpochDifficulty(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	// This calls the keeper and gets an answer
+	result, err := keeper.GetCurrentEpochDifficulty(ctx)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	// Now we format the answer as a response
+	response := types.QueryResGetCurrentEpochDifficulty{
+		Res: result,
+	}
+
+	// And we serialize that response as JSON
+	res, marshalErr := codec.MarshalJSONIndent(keeper.cdc, response)
+	if marshalErr != nil {
+		return []byte{}, types.ErrMarshalJSON(types.DefaultCodespace)
+	}
+	return res, nil
+}
+
+func queryGetPrevEpochDifficulty(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	// This calls the keeper and gets an answer
+	result, err := keeper.GetPrevEpochDifficulty(ctx)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	// Now we format the answer as a response
+	response := types.QueryResGetPrevEpochDifficulty{
+		Res: result,
+	}
+
+	// And we serialize that response as JSON
+	res, marshalErr := codec.MarshalJSONIndent(keeper.cdc, response)
+	if marshalErr != nil {
+		return []byte{}, types.ErrMarshalJSON(types.DefaultCodespace)
+	}
+	return res, nil
+}
+
+func queryFindHeight(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params types.QueryParamsFindHeight
+
+	unmarshallErr := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if unmarshallErr != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to parse params: %s", unmarshallErr))
+	}
+
+	// This calls the keeper with the parsed arguments, and gets an answer
+	result, err := keeper.FindHeight(ctx, params.DigestLE)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	// Now we format the answer as a response
+	response := types.QueryResFindHeight{
+		Params: params,
+		Res:    result,
+	}
+
+	// And we serialize that response as JSON
+	res, marshalErr := codec.MarshalJSONIndent(keeper.cdc, response)
+	if marshalErr != nil {
+		return []byte{}, types.ErrMarshalJSON(types.DefaultCodespace)
+	}
+	return res, nil
+}
+
+func queryGetChainParams(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	// This calls the keeper and gets an answer
+	result := keeper.GetChainParams(ctx)
+
+	// Now we format the answer as a response
+	response := types.QueryResGetChainParams{
+		Res: result,
+	}
+
+	// And we serialize that response as JSON
+	res, marshalErr := codec.MarshalJSONIndent(keeper.cdc, response)
+	if marshalErr != nil {
+		return []byte{}, types.ErrMarshalJSON(types.DefaultCodespace)
+	}
+	return res, nil
+}
+
+func queryParams(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	// This calls the keeper and gets an answer
+	result := keeper.GetParams(ctx)
+
+	// Now we format the answer as a response
+	response := types.QueryResParams{
+		Res: result,
+	}
+
+	// And we serialize that response as JSON
+	res, marshalErr := codec.MarshalJSONIndent(keeper.cdc, response)
+	if marshalErr != nil {
+		return []byte{}, types.ErrMarshalJSON(types.DefaultCodespace)
+	}
+	return res, nil
+}
+
+func queryGetTips(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	// This calls the keeper and gets an answer
+	result, err := keeper.GetChainTips(ctx)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	// Now we format the answer as a response
+	response := types.QueryResGetTips{
+		Res: result,
+	}
+
+	// And we serialize that response as JSON
+	res, marshalErr := codec.MarshalJSONIndent(keeper.cdc, response)
+	if marshalErr != nil {
+		return []byte{}, types.ErrMarshalJSON(types.DefaultCodespace)
+	}
+	return res, nil
+}
+
+func queryGetMMRRoot(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	// This calls the keeper and gets an answer
+	result, err := keeper.GetMMRRoot(ctx)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	// Now we format the answer as a response
+	response := types.QueryResGetMMRRoot{
+		Res: result,
+	}
+
+	// And we serialize that response as JSON
+	res, marshalErr := codec.MarshalJSONIndent(keeper.cdc, response)
+	if marshalErr != nil {
+		return []byte{}, types.ErrMarshalJSON(types.DefaultCodespace)
+	}
+	return res, nil
+}
+
+func queryGetMMRProof(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params types.QueryParamsGetMMRProof
+
+	unmarshallErr := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if unmarshallErr != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to parse params: %s", unmarshallErr))
+	}
+
+	// This calls the keeper with the parsed arguments, and gets an answer
+	result, err := keeper.GetMMRProof(ctx, params.Height)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	// Now we format the answer as a response
+	response := types.QueryResGetMMRProof{
+		Params: params,
+		Res:    result,
+	}
+
+	// And we serialize that response as JSON
+	res, marshalErr := codec.MarshalJSONIndent(keeper.cdc, response)
+	if marshalErr != nil {
+		return []byte{}, types.ErrMarshalJSON(types.DefaultCodespace)
+	}
+	return res, nil
+}
+
+func queryGetHeaders(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params types.QueryParamsGetHeaders
+
+	unmarshallErr := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if unmarshallErr != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to parse params: %s", unmarshallErr))
+	}
+
+	// This calls the keeper with the parsed arguments, and gets an answer
+	var result []types.BitcoinHeader
+	if params.DigestLE != (types.Hash256Digest{}) {
+		result, err = keeper.GetHeadersFromDigest(ctx, params.DigestLE, params.Count)
+	} else {
+		result, err = keeper.GetHeaders(ctx, params.Height, params.Count)
+	}
+	if err != nil {
+		return []byte{}, err
+	}
+
+	// Now we format the answer as a response
+	response := types.QueryResGetHeaders{
+		Params: params,
+		Res:    result,
+	}
+
+	// And we serialize that response as JSON
+	res, marshalErr := codec.MarshalJSONIndent(keeper.cdc, response)
+	if marshalErr != nil {
+		return []byte{}, types.ErrMarshalJSON(types.DefaultCodespace)
+	}
+	return res, nil
+}
+
+func queryLocateHeaders(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params types.QueryParamsLocateHeaders
+
+	unmarshallErr := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if unmarshallErr != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to parse params: %s", unmarshallErr))
+	}
+
+	// This calls the keeper with the parsed arguments, and gets an answer
+	result, err := keeper.LocateHeaders(ctx, params.Locator, params.Count)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	// Now we format the answer as a response
+	response := types.QueryResLocateHeaders{
+		Params: params,
+		Res:    result,
+	}
+
+	// And we serialize that response as JSON
+	res, marshalErr := codec.MarshalJSONIndent(keeper.cdc, response)
+	if marshalErr != nil {
+		return []byte{}, types.ErrMarshalJSON(types.DefaultCodespace)
+	}
+	return res, nil
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/summa-tx/bitcoin-spv/golang/btcspv"
 	"github.com/summa-tx/relays/golang/x/relay/types"
 	abci "github.com/tendermint/tendermint/abci/types"
 )
@@ -448,7 +449,8 @@ func (s *KeeperSuite) TestQueryGetRequest() {
 	s.Equal(sdk.CodeType(types.UnknownRequest), err.Code())
 
 	// Set Request
-	err = s.Keeper.setRequest(s.Context, []byte{0}, []byte{0}, 0, 0, types.Local, nil)
+	creator := getAccAddress()
+	err = s.Keeper.setRequest(s.Context, creator, []byte{0}, []byte{0}, 0, 0, types.Local, nil, types.RequestOptions{})
 	s.SDKNil(err)
 
 	// Use querier handler to get request
@@ -460,5 +462,537 @@ func (s *KeeperSuite) TestQueryGetRequest() {
 
 	unmarshallErr := types.ModuleCdc.UnmarshalJSON(res, &result)
 	s.Nil(unmarshallErr)
-	s.Equal(s.Fixtures.RequestTestCases.EmptyRequest, result.Res)
+	expected := s.Fixtures.RequestTestCases.EmptyRequest
+	expected.Creator = creator
+	s.Equal(expected, result.Res)
+	s.Equal(0, len(result.Fills))
+
+	// includes the request's fills
+	header := s.Fixtures.ValidatorTestCases.ValidateProof[0].Proof.ConfirmingHeader
+	s.fillAt(header, types.RequestID{})
+	res, err = querier(s.Context, path, req)
+	s.SDKNil(err)
+	unmarshallErr = types.ModuleCdc.UnmarshalJSON(res, &result)
+	s.Nil(unmarshallErr)
+	s.Equal(uint32(1), result.Res.NumFills)
+	s.False(result.Res.ActiveState)
+	s.Equal([]types.Fill{{ID: types.RequestID{}, TxID: header.Hash, ConfirmingDigest: header.Hash}}, result.Fills)
+}
+
+func (s *KeeperSuite) TestQueryGetProvenTx() {
+	_, main := s.initPruneTest(4)
+	querier := NewQuerier(s.Keeper)
+
+	path := []string{"getproventx"}
+
+	// Errors if it cannot unmarshal req data
+	req := abci.RequestQuery{
+		Path: "custom/relay/getproventx",
+		Data: []byte{0},
+	}
+	_, err := querier(s.Context, path, req)
+	s.Equal(sdk.CodeType(1), err.Code())
+
+	params := types.QueryParamsGetProvenTx{
+		TxID: main[1].Hash,
+	}
+	marshalledParams, marshalErr := json.Marshal(params)
+	s.Nil(marshalErr)
+	req = abci.RequestQuery{
+		Path: "custom/relay/getproventx",
+		Data: marshalledParams,
+	}
+
+	// Errors if the transaction has filled no requests
+	_, err = querier(s.Context, path, req)
+	s.Equal(sdk.CodeType(types.UnknownProvenTx), err.Code())
+
+	err = s.Keeper.setRequest(s.Context, nil, []byte{}, []byte{}, 0, 0, types.Local, nil, types.RequestOptions{})
+	s.SDKNil(err)
+	s.fillAt(main[1], types.RequestID{})
+
+	res, err := querier(s.Context, path, req)
+	s.SDKNil(err)
+
+	var result types.QueryResGetProvenTx
+
+	unmarshallErr := types.ModuleCdc.UnmarshalJSON(res, &result)
+	s.Nil(unmarshallErr)
+	s.Equal(main[1].Hash, result.Res.TxID)
+	s.Equal(main[1].Hash, result.Res.ConfirmingDigest)
+	s.Equal(main[1], result.Res.ConfirmingHeader)
+	s.Equal(1, len(result.Res.Requests))
+	s.Equal(types.RequestID{}, result.Res.Requests[0].ID)
+}
+
+func (s *KeeperSuite) TestQueryGetHeader() {
+	genesis := s.Fixtures.HeaderTestCases.ValidateDiffChange[0].Anchor
+	epochStart := s.Fixtures.HeaderTestCases.ValidateDiffChange[0].PrevEpochStart
+	querier := NewQuerier(s.Keeper)
+
+	path := []string{"getheader"}
+
+	// Errors if it cannot unmarshal req data
+	req := abci.RequestQuery{
+		Path: "custom/relay/getheader",
+		Data: []byte{0},
+	}
+	_, err := querier(s.Context, path, req)
+	s.Equal(sdk.CodeType(1), err.Code())
+
+	params := types.QueryParamsGetHeader{
+		DigestLE: genesis.Hash,
+	}
+	marshalledParams, marshalErr := json.Marshal(params)
+	s.Nil(marshalErr)
+
+	req = abci.RequestQuery{
+		Path: "custom/relay/getheader",
+		Data: marshalledParams,
+	}
+
+	// Errors if the header is not found
+	_, err = querier(s.Context, path, req)
+	s.Equal(sdk.CodeType(types.UnknownBlock), err.Code())
+
+	err = s.Keeper.SetGenesisState(s.Context, genesis, epochStart)
+	s.SDKNil(err)
+
+	res, err := querier(s.Context, path, req)
+	s.SDKNil(err)
+
+	var result types.QueryResGetHeader
+
+	unmarshallErr := types.ModuleCdc.UnmarshalJSON(res, &result)
+	s.Nil(unmarshallErr)
+	s.Equal(genesis, result.Res)
+}
+
+func (s *KeeperSuite) TestQueryGetHeaderByHeight() {
+	genesis := s.Fixtures.HeaderTestCases.ValidateDiffChange[0].Anchor
+	epochStart := s.Fixtures.HeaderTestCases.ValidateDiffChange[0].PrevEpochStart
+	querier := NewQuerier(s.Keeper)
+
+	path := []string{"getheaderbyheight"}
+
+	// Errors if it cannot unmarshal req data
+	req := abci.RequestQuery{
+		Path: "custom/relay/getheaderbyheight",
+		Data: []byte{0},
+	}
+	_, err := querier(s.Context, path, req)
+	s.Equal(sdk.CodeType(1), err.Code())
+
+	params := types.QueryParamsGetHeaderByHeight{
+		Height: genesis.Height,
+	}
+	marshalledParams, marshalErr := json.Marshal(params)
+	s.Nil(marshalErr)
+
+	req = abci.RequestQuery{
+		Path: "custom/relay/getheaderbyheight",
+		Data: marshalledParams,
+	}
+
+	// Errors if no block is indexed at the height
+	_, err = querier(s.Context, path, req)
+	s.Equal(sdk.CodeType(types.UnknownHeight), err.Code())
+
+	err = s.Keeper.SetGenesisState(s.Context, genesis, epochStart)
+	s.SDKNil(err)
+
+	res, err := querier(s.Context, path, req)
+	s.SDKNil(err)
+
+	var result types.QueryResGetHeaderByHeight
+
+	unmarshallErr := types.ModuleCdc.UnmarshalJSON(res, &result)
+	s.Nil(unmarshallErr)
+	s.Equal(genesis, result.Res)
+}
+
+func (s *KeeperSuite) TestQueryGetChainWork() {
+	genesis := s.Fixtures.HeaderTestCases.ValidateDiffChange[0].Anchor
+	epochStart := s.Fixtures.HeaderTestCases.ValidateDiffChange[0].PrevEpochStart
+	querier := NewQuerier(s.Keeper)
+
+	path := []string{"getchainwork"}
+
+	// Errors if it cannot unmarshal req data
+	req := abci.RequestQuery{
+		Path: "custom/relay/getchainwork",
+		Data: []byte{0},
+	}
+	_, err := querier(s.Context, path, req)
+	s.Equal(sdk.CodeType(1), err.Code())
+
+	params := types.QueryParamsGetChainWork{
+		DigestLE: genesis.Hash,
+	}
+	marshalledParams, marshalErr := json.Marshal(params)
+	s.Nil(marshalErr)
+
+	req = abci.RequestQuery{
+		Path: "custom/relay/getchainwork",
+		Data: marshalledParams,
+	}
+
+	// Errors if the header is not found
+	_, err = querier(s.Context, path, req)
+	s.Equal(sdk.CodeType(types.UnknownBlock), err.Code())
+
+	err = s.Keeper.SetGenesisState(s.Context, genesis, epochStart)
+	s.SDKNil(err)
+
+	res, err := querier(s.Context, path, req)
+	s.SDKNil(err)
+
+	var result types.QueryResGetChainWork
+
+	unmarshallErr := types.ModuleCdc.UnmarshalJSON(res, &result)
+	s.Nil(unmarshallErr)
+	s.Equal(calculateWork(genesis.Raw), result.Res)
+}
+
+func (s *KeeperSuite) TestQueryGetChainParams() {
+	querier := NewQuerier(s.Keeper)
+
+	path := []string{"getchainparams"}
+
+	req := abci.RequestQuery{
+		Path: "custom/relay/getchainparams",
+		Data: []byte{},
+	}
+
+	s.Keeper.SetChainParams(s.Context, types.SignetParams())
+
+	res, err := querier(s.Context, path, req)
+	s.SDKNil(err)
+
+	var result types.QueryResGetChainParams
+
+	unmarshallErr := types.ModuleCdc.UnmarshalJSON(res, &result)
+	s.Nil(unmarshallErr)
+	s.Equal(types.SignetParams(), result.Res)
+}
+
+func (s *KeeperSuite) TestQueryParams() {
+	querier := NewQuerier(s.Keeper)
+
+	path := []string{"params"}
+
+	req := abci.RequestQuery{
+		Path: "custom/relay/params",
+		Data: []byte{},
+	}
+
+	params := types.DefaultParams()
+	params.ProofAncestorLimit = 100
+	s.Keeper.SetParams(s.Context, params)
+
+	res, err := querier(s.Context, path, req)
+	s.SDKNil(err)
+
+	var result types.QueryResParams
+
+	unmarshallErr := types.ModuleCdc.UnmarshalJSON(res, &result)
+	s.Nil(unmarshallErr)
+	s.Equal(params, result.Res)
+}
+
+func (s *KeeperSuite) TestQueryGetTips() {
+	tv := s.Fixtures.ChainTestCases.IsMostRecentCA
+	querier := NewQuerier(s.Keeper)
+
+	path := []string{"gettips"}
+
+	req := abci.RequestQuery{
+		Path: "custom/relay/gettips",
+		Data: []byte{},
+	}
+
+	// errors before the relay is initialized
+	_, err := querier(s.Context, path, req)
+	s.Equal(sdk.CodeType(types.BadHash256Digest), err.Code())
+
+	err = s.Keeper.SetGenesisState(s.Context, tv.Genesis, tv.OldPeriodStart)
+	s.SDKNil(err)
+
+	res, err := querier(s.Context, path, req)
+	s.SDKNil(err)
+
+	var result types.QueryResGetTips
+
+	unmarshallErr := types.ModuleCdc.UnmarshalJSON(res, &result)
+	s.Nil(unmarshallErr)
+	s.Equal(1, len(result.Res))
+	s.Equal(tv.Genesis.Hash, result.Res[0].Digest)
+	s.Equal(types.TipStatusActive, result.Res[0].Status)
+}
+
+func (s *KeeperSuite) TestQueryGetMMRRoot() {
+	tv := s.Fixtures.ChainTestCases.IsMostRecentCA
+	querier := NewQuerier(s.Keeper)
+
+	path := []string{"getmmrroot"}
+
+	req := abci.RequestQuery{
+		Path: "custom/relay/getmmrroot",
+		Data: []byte{},
+	}
+
+	// errors before the relay is initialized
+	_, err := querier(s.Context, path, req)
+	s.Equal(sdk.CodeType(types.BadHash256Digest), err.Code())
+
+	err = s.Keeper.SetGenesisState(s.Context, tv.Genesis, tv.OldPeriodStart)
+	s.SDKNil(err)
+
+	res, err := querier(s.Context, path, req)
+	s.SDKNil(err)
+
+	var result types.QueryResGetMMRRoot
+
+	unmarshallErr := types.ModuleCdc.UnmarshalJSON(res, &result)
+	s.Nil(unmarshallErr)
+	s.Equal(uint32(1), result.Res.LeafCount)
+	s.Equal(tv.Genesis.Height, result.Res.BaseHeight)
+	s.Equal(types.BagMMRPeaks(tv.Genesis.Height, 1, []types.Hash256Digest{tv.Genesis.Hash}), result.Res.Root)
+}
+
+func (s *KeeperSuite) TestQueryGetMMRProof() {
+	tv := s.Fixtures.ChainTestCases.IsMostRecentCA
+	querier := NewQuerier(s.Keeper)
+
+	path := []string{"getmmrproof"}
+
+	// Errors if it cannot unmarshal req data
+	req := abci.RequestQuery{
+		Path: "custom/relay/getmmrproof",
+		Data: []byte{0},
+	}
+	_, err := querier(s.Context, path, req)
+	s.Equal(sdk.CodeType(1), err.Code())
+
+	params := types.QueryParamsGetMMRProof{
+		Height: tv.Genesis.Height,
+	}
+	marshalledParams, marshalErr := json.Marshal(params)
+	s.Nil(marshalErr)
+
+	req = abci.RequestQuery{
+		Path: "custom/relay/getmmrproof",
+		Data: marshalledParams,
+	}
+
+	err = s.Keeper.SetGenesisState(s.Context, tv.Genesis, tv.OldPeriodStart)
+	s.SDKNil(err)
+
+	res, err := querier(s.Context, path, req)
+	s.SDKNil(err)
+
+	var result types.QueryResGetMMRProof
+
+	unmarshallErr := types.ModuleCdc.UnmarshalJSON(res, &result)
+	s.Nil(unmarshallErr)
+	s.Equal(tv.Genesis.Hash, result.Res.Leaf)
+	root, err := s.Keeper.GetMMRRoot(s.Context)
+	s.SDKNil(err)
+	s.True(result.Res.Verify(root))
+}
+
+func (s *KeeperSuite) TestQueryGetHeaders() {
+	tv := s.Fixtures.ChainTestCases.IsMostRecentCA
+	pre := tv.PreRetargetChain
+	querier := NewQuerier(s.Keeper)
+
+	path := []string{"getheaders"}
+
+	// Errors if it cannot unmarshal req data
+	req := abci.RequestQuery{
+		Path: "custom/relay/getheaders",
+		Data: []byte{0},
+	}
+	_, err := querier(s.Context, path, req)
+	s.Equal(sdk.CodeType(1), err.Code())
+
+	err = s.Keeper.SetGenesisState(s.Context, tv.Genesis, tv.OldPeriodStart)
+	s.SDKNil(err)
+	err = s.Keeper.IngestHeaderChain(s.Context, pre)
+	s.SDKNil(err)
+	err = s.Keeper.MarkNewHeaviest(s.Context, tv.Genesis.Hash, tv.Genesis.Raw, pre[len(pre)-1].Raw, 20)
+	s.SDKNil(err)
+
+	// starts at a height
+	params := types.QueryParamsGetHeaders{
+		Height: tv.Genesis.Height,
+		Count:  2,
+	}
+	marshalledParams, marshalErr := json.Marshal(params)
+	s.Nil(marshalErr)
+	req = abci.RequestQuery{
+		Path: "custom/relay/getheaders",
+		Data: marshalledParams,
+	}
+
+	res, err := querier(s.Context, path, req)
+	s.SDKNil(err)
+
+	var result types.QueryResGetHeaders
+
+	unmarshallErr := types.ModuleCdc.UnmarshalJSON(res, &result)
+	s.Nil(unmarshallErr)
+	s.Equal([]types.BitcoinHeader{tv.Genesis, pre[0]}, result.Res)
+
+	// starts at a digest
+	params = types.QueryParamsGetHeaders{
+		DigestLE: pre[0].Hash,
+		Count:    2,
+	}
+	marshalledParams, marshalErr = json.Marshal(params)
+	s.Nil(marshalErr)
+	req.Data = marshalledParams
+
+	res, err = querier(s.Context, path, req)
+	s.SDKNil(err)
+	unmarshallErr = types.ModuleCdc.UnmarshalJSON(res, &result)
+	s.Nil(unmarshallErr)
+	s.Equal(pre[:2], result.Res)
+}
+
+func (s *KeeperSuite) TestQueryLocateHeaders() {
+	tv := s.Fixtures.ChainTestCases.IsMostRecentCA
+	pre := tv.PreRetargetChain
+	querier := NewQuerier(s.Keeper)
+
+	path := []string{"locateheaders"}
+
+	// Errors if it cannot unmarshal req data
+	req := abci.RequestQuery{
+		Path: "custom/relay/locateheaders",
+		Data: []byte{0},
+	}
+	_, err := querier(s.Context, path, req)
+	s.Equal(sdk.CodeType(1), err.Code())
+
+	params := types.QueryParamsLocateHeaders{
+		Locator: []types.Hash256Digest{pre[1].Hash, tv.Genesis.Hash},
+		Count:   2,
+	}
+	marshalledParams, marshalErr := json.Marshal(params)
+	s.Nil(marshalErr)
+	req = abci.RequestQuery{
+		Path: "custom/relay/locateheaders",
+		Data: marshalledParams,
+	}
+
+	// Errors if no locator digest is on the best chain
+	_, err = querier(s.Context, path, req)
+	s.Equal(sdk.CodeType(types.NoLocatorMatch), err.Code())
+
+	err = s.Keeper.SetGenesisState(s.Context, tv.Genesis, tv.OldPeriodStart)
+	s.SDKNil(err)
+	err = s.Keeper.IngestHeaderChain(s.Context, pre)
+	s.SDKNil(err)
+
+	// pre is not yet on the best chain
+	res, err := querier(s.Context, path, req)
+	s.SDKNil(err)
+
+	var result types.QueryResLocateHeaders
+
+	unmarshallErr := types.ModuleCdc.UnmarshalJSON(res, &result)
+	s.Nil(unmarshallErr)
+	s.Equal(tv.Genesis, result.Res.Fork)
+	s.Equal(0, len(result.Res.Headers))
+
+	err = s.Keeper.MarkNewHeaviest(s.Context, tv.Genesis.Hash, tv.Genesis.Raw, pre[len(pre)-1].Raw, 20)
+	s.SDKNil(err)
+
+	res, err = querier(s.Context, path, req)
+	s.SDKNil(err)
+	unmarshallErr = types.ModuleCdc.UnmarshalJSON(res, &result)
+	s.Nil(unmarshallErr)
+	s.Equal(pre[1], result.Res.Fork)
+	s.Equal(pre[2:4], result.Res.Headers)
+}
+
+func (s *KeeperSuite) TestQueryGetEpochDifficulties() {
+	tv := s.Fixtures.ChainTestCases.IsMostRecentCA
+	querier := NewQuerier(s.Keeper)
+
+	currentPath := []string{"getcurrentepochdifficulty"}
+	currentReq := abci.RequestQuery{
+		Path: "custom/relay/getcurrentepochdifficulty",
+		Data: []byte{},
+	}
+	prevPath := []string{"getprevepochdifficulty"}
+	prevReq := abci.RequestQuery{
+		Path: "custom/relay/getprevepochdifficulty",
+		Data: []byte{},
+	}
+
+	// errors before the relay is initialized
+	_, err := querier(s.Context, currentPath, currentReq)
+	s.Equal(sdk.CodeType(types.BadHash256Digest), err.Code())
+	_, err = querier(s.Context, prevPath, prevReq)
+	s.Equal(sdk.CodeType(types.BadHash256Digest), err.Code())
+
+	err = s.Keeper.SetGenesisState(s.Context, tv.Genesis, tv.OldPeriodStart)
+	s.SDKNil(err)
+
+	res, err := querier(s.Context, currentPath, currentReq)
+	s.SDKNil(err)
+	var current types.QueryResGetCurrentEpochDifficulty
+	unmarshallErr := types.ModuleCdc.UnmarshalJSON(res, &current)
+	s.Nil(unmarshallErr)
+	s.Equal(btcspv.ExtractDifficulty(tv.Genesis.Raw), current.Res)
+
+	res, err = querier(s.Context, prevPath, prevReq)
+	s.SDKNil(err)
+	var prev types.QueryResGetPrevEpochDifficulty
+	unmarshallErr = types.ModuleCdc.UnmarshalJSON(res, &prev)
+	s.Nil(unmarshallErr)
+	s.Equal(sdk.ZeroUint(), prev.Res)
+}
+
+func (s *KeeperSuite) TestQueryFindHeight() {
+	tv := s.Fixtures.ChainTestCases.IsMostRecentCA
+	querier := NewQuerier(s.Keeper)
+
+	path := []string{"findheight"}
+
+	// Errors if it cannot unmarshal req data
+	req := abci.RequestQuery{
+		Path: "custom/relay/findheight",
+		Data: []byte{0},
+	}
+	_, err := querier(s.Context, path, req)
+	s.Equal(sdk.CodeType(1), err.Code())
+
+	params := types.QueryParamsFindHeight{
+		DigestLE: tv.Genesis.Hash,
+	}
+	marshalledParams, marshalErr := json.Marshal(params)
+	s.Nil(marshalErr)
+	req = abci.RequestQuery{
+		Path: "custom/relay/findheight",
+		Data: marshalledParams,
+	}
+
+	// Errors if the header is unknown
+	_, err = querier(s.Context, path, req)
+	s.Equal(sdk.CodeType(types.UnknownBlock), err.Code())
+
+	err = s.Keeper.SetGenesisState(s.Context, tv.Genesis, tv.OldPeriodStart)
+	s.SDKNil(err)
+
+	res, err := querier(s.Context, path, req)
+	s.SDKNil(err)
+
+	var result types.QueryResFindHeight
+
+	unmarshallErr := types.ModuleCdc.UnmarshalJSON(res, &result)
+	s.Nil(unmarshallErr)
+	s.Equal(tv.Genesis.Height, result.Res)
 }
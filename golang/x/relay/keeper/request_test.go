@@ -41,7 +41,7 @@ func (s *KeeperSuite) TestIncrementID() {
 func (s *KeeperSuite) TestHasRequest() {
 	hasRequest := s.Keeper.hasRequest(s.Context, types.RequestID{})
 	s.Equal(false, hasRequest)
-	requestErr := s.Keeper.setRequest(s.Context, []byte{0}, []byte{0}, 0, 4, types.Local, nil)
+	requestErr := s.Keeper.setRequest(s.Context, nil, []byte{0}, []byte{0}, 0, 4, types.Local, nil, types.RequestOptions{})
 	s.Nil(requestErr)
 	hasRequest = s.Keeper.hasRequest(s.Context, types.RequestID{})
 	s.Equal(true, hasRequest)
@@ -52,7 +52,7 @@ func (s *KeeperSuite) TestSetRequest() {
 	idTag := []byte(types.RequestIDTag)
 	store.Set(idTag, bytes.Repeat([]byte{9}, 9))
 
-	err := s.Keeper.setRequest(s.Context, []byte{0}, []byte{0}, 0, 0, types.Local, nil)
+	err := s.Keeper.setRequest(s.Context, nil, []byte{0}, []byte{0}, 0, 0, types.Local, nil, types.RequestOptions{})
 	s.Equal(sdk.CodeType(107), err.Code())
 }
 
@@ -62,7 +62,7 @@ func (s *KeeperSuite) TestSetRequestState() {
 	s.Equal(sdk.CodeType(601), activeErr.Code())
 
 	// set request
-	requestErr := s.Keeper.setRequest(s.Context, []byte{1}, []byte{1}, 0, 0, types.Local, nil)
+	requestErr := s.Keeper.setRequest(s.Context, nil, []byte{1}, []byte{1}, 0, 0, types.Local, nil, types.RequestOptions{})
 	s.Nil(requestErr)
 	// change active state to false
 	activeErr = s.Keeper.setRequestState(s.Context, types.RequestID{}, false)
@@ -73,13 +73,50 @@ func (s *KeeperSuite) TestSetRequestState() {
 	s.Equal(false, deactivatedRequest.ActiveState)
 }
 
+func (s *KeeperSuite) TestCancelRequest() {
+	creator := getAccAddress()
+	other := sdk.AccAddress(bytes.Repeat([]byte{1}, 20))
+
+	// errors if request is not found
+	err := s.Keeper.CancelRequest(s.Context, creator, types.RequestID{})
+	s.Equal(sdk.CodeType(types.UnknownRequest), err.Code())
+
+	err = s.Keeper.setRequest(s.Context, creator, []byte{0}, []byte{0}, 0, 0, types.Local, nil, types.RequestOptions{})
+	s.SDKNil(err)
+	request, err := s.Keeper.getRequest(s.Context, types.RequestID{})
+	s.SDKNil(err)
+	s.Equal(creator, request.Creator)
+
+	// only the creator may cancel
+	err = s.Keeper.CancelRequest(s.Context, other, types.RequestID{})
+	s.Equal(sdk.CodeType(types.NotRequestOwner), err.Code())
+
+	err = s.Keeper.CancelRequest(s.Context, creator, types.RequestID{})
+	s.SDKNil(err)
+	request, err = s.Keeper.getRequest(s.Context, types.RequestID{})
+	s.SDKNil(err)
+	s.False(request.ActiveState)
+	events := s.Context.EventManager().Events()
+	s.Equal("request_cancelled", events[len(events)-1].Type)
+
+	// errors if the request is closed
+	err = s.Keeper.CancelRequest(s.Context, creator, types.RequestID{})
+	s.Equal(sdk.CodeType(types.ClosedRequest), err.Code())
+
+	// requests without a creator can not be cancelled
+	err = s.Keeper.setRequest(s.Context, nil, []byte{0}, []byte{0}, 0, 0, types.Local, nil, types.RequestOptions{})
+	s.SDKNil(err)
+	err = s.Keeper.CancelRequest(s.Context, nil, types.RequestID{0, 0, 0, 0, 0, 0, 0, 1})
+	s.Equal(sdk.CodeType(types.NotRequestOwner), err.Code())
+}
+
 func (s *KeeperSuite) TestGetRequest() {
 	requestRes := s.Fixtures.RequestTestCases.EmptyRequest
 	request, err := s.Keeper.getRequest(s.Context, types.RequestID{})
 	s.Equal(sdk.CodeType(601), err.Code())
 	s.Equal(types.ProofRequest{}, request)
 
-	requestErr := s.Keeper.setRequest(s.Context, []byte{0}, []byte{0}, 0, 0, types.Local, nil)
+	requestErr := s.Keeper.setRequest(s.Context, nil, []byte{0}, []byte{0}, 0, 0, types.Local, nil, types.RequestOptions{})
 	s.Nil(requestErr)
 
 	request, err = s.Keeper.getRequest(s.Context, types.RequestID{})
@@ -102,7 +139,7 @@ func (s *KeeperSuite) TestCheckRequests() {
 	s.Equal(sdk.CodeType(601), err.Code())
 
 	// set request
-	requestErr := s.Keeper.setRequest(s.Context, []byte{1}, []byte{1}, 0, 0, types.Local, nil)
+	requestErr := s.Keeper.setRequest(s.Context, nil, []byte{1}, []byte{1}, 0, 0, types.Local, nil, types.RequestOptions{})
 	s.Nil(requestErr)
 	// change active state to false
 	activeErr := s.Keeper.setRequestState(s.Context, types.RequestID{}, false)
@@ -134,7 +171,7 @@ func (s *KeeperSuite) TestCheckRequests() {
 	out, outErr := btcspv.ExtractOutputAtIndex(v.Vout, uint(v.OutputIdx))
 	s.Nil(outErr)
 	// out[8:] extracts the output script which we use to set the request
-	requestErr = s.Keeper.setRequest(s.Context, []byte{0}, out[8:], 1000, 0, types.Local, nil)
+	requestErr = s.Keeper.setRequest(s.Context, nil, []byte{0}, out[8:], 1000, 0, types.Local, nil, types.RequestOptions{})
 	s.SDKNil(requestErr)
 	err = s.Keeper.checkRequests(
 		s.Context,
@@ -146,7 +183,7 @@ func (s *KeeperSuite) TestCheckRequests() {
 	s.Equal(sdk.CodeType(608), err.Code())
 
 	// Errors if input value does not equal spends value
-	requestErr = s.Keeper.setRequest(s.Context, []byte{1}, []byte{}, 0, 255, types.Local, nil)
+	requestErr = s.Keeper.setRequest(s.Context, nil, []byte{1}, []byte{}, 0, 255, types.Local, nil, types.RequestOptions{})
 	s.SDKNil(requestErr)
 	err = s.Keeper.checkRequests(
 		s.Context,
@@ -162,7 +199,7 @@ func (s *KeeperSuite) TestCheckRequests() {
 	s.Nil(extractErr)
 	outpoint := btcspv.ExtractOutpoint(in)
 	// out[8:] extracts the output script which we use to set the request
-	requestErr = s.Keeper.setRequest(s.Context, outpoint, out[8:], 10, 255, types.Local, nil)
+	requestErr = s.Keeper.setRequest(s.Context, nil, outpoint, out[8:], 10, 255, types.Local, nil, types.RequestOptions{})
 	s.SDKNil(requestErr)
 	err = s.Keeper.checkRequests(
 		s.Context,
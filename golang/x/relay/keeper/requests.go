@@ -3,7 +3,6 @@ package keeper
 import (
 	"bytes"
 	"encoding/binary"
-	"encoding/json"
 
 	btcspv "github.com/summa-tx/bitcoin-spv/golang/btcspv"
 	"github.com/summa-tx/relays/golang/x/relay/types"
@@ -24,9 +23,50 @@ func (k Keeper) hasRequest(ctx sdk.Context, id types.RequestID) bool {
 	return store.Has(id[:])
 }
 
-func (k Keeper) setRequest(ctx sdk.Context, spends []byte, pays []byte, paysValue uint64, numConfs uint8, origin types.Origin, action types.HexBytes) sdk.Error {
+// storeRequest writes a request to the store under the given ID, and updates
+// the expiry indexes
+func (k Keeper) storeRequest(ctx sdk.Context, id types.RequestID, request types.ProofRequest) sdk.Error {
 	store := k.getRequestStore(ctx)
 
+	buf, marshalErr := k.cdc.MarshalBinaryBare(request)
+	if marshalErr != nil {
+		return types.ErrExternal(types.DefaultCodespace, marshalErr)
+	}
+	store.Set(id[:], buf)
+	k.indexRequestExpiry(ctx, id, request)
+	return nil
+}
+
+// decodeRequest deserializes a stored request
+func (k Keeper) decodeRequest(buf []byte) (types.ProofRequest, sdk.Error) {
+	var request types.ProofRequest
+	err := k.cdc.UnmarshalBinaryBare(buf, &request)
+	if err != nil {
+		return types.ProofRequest{}, types.ErrExternal(types.DefaultCodespace, err)
+	}
+	return request, nil
+}
+
+// validateRequestLengths checks the request's fields against the length limits in the module params
+func (k Keeper) validateRequestLengths(ctx sdk.Context, spends []byte, pays []byte, action types.HexBytes) sdk.Error {
+	params := k.GetParams(ctx)
+	if len(spends) != int(params.SpendsLength) && len(spends) != 0 {
+		return types.ErrSpendsLength(types.DefaultCodespace, params.SpendsLength)
+	}
+	if len(pays) > int(params.MaxPaysLength) {
+		return types.ErrPaysLength(types.DefaultCodespace, params.MaxPaysLength)
+	}
+	if len(action) > int(params.MaxActionLength) {
+		return types.ErrActionLength(types.DefaultCodespace, params.MaxActionLength)
+	}
+	return nil
+}
+
+func (k Keeper) emitRequestCancelled(ctx sdk.Context, id types.RequestID, creator sdk.AccAddress) {
+	ctx.EventManager().EmitEvent(types.NewRequestCancelledEvent(id, creator))
+}
+
+func (k Keeper) setRequest(ctx sdk.Context, creator sdk.AccAddress, spends []byte, pays []byte, paysValue uint64, numConfs uint8, origin types.Origin, action types.HexBytes, options types.RequestOptions) sdk.Error {
 	var spendsDigest types.Hash256Digest
 	if len(spends) == 0 {
 		spendsDigest = types.Hash256Digest{}
@@ -41,6 +81,8 @@ func (k Keeper) setRequest(ctx sdk.Context, spends []byte, pays []byte, paysValu
 		paysDigest = btcspv.Hash256(pays)
 	}
 
+	maxFills := options.Fills()
+
 	request := types.ProofRequest{
 		Spends:      spendsDigest,
 		Pays:        paysDigest,
@@ -49,6 +91,11 @@ func (k Keeper) setRequest(ctx sdk.Context, spends []byte, pays []byte, paysValu
 		NumConfs:    numConfs,
 		Origin:      origin,
 		Action:      action,
+		Creator:     creator,
+		MaxFills:    maxFills,
+
+		ExpiryHeight:    options.ExpiryHeight,
+		BTCExpiryHeight: options.BTCExpiryHeight,
 	}
 
 	// When a new request comes in, get the id and use it to store request
@@ -57,11 +104,19 @@ func (k Keeper) setRequest(ctx sdk.Context, spends []byte, pays []byte, paysValu
 		return err
 	}
 
-	buf, marshalErr := json.Marshal(request)
-	if marshalErr != nil {
-		return types.ErrMarshalJSON(types.DefaultCodespace)
+	escrow, err := k.escrowBounty(ctx, creator, options.Bounty, maxFills)
+	if err != nil {
+		return err
+	}
+	if !escrow.IsZero() {
+		request.Bounty = options.Bounty
+		request.Escrow = escrow
+	}
+
+	err = k.storeRequest(ctx, id, request)
+	if err != nil {
+		return err
 	}
-	store.Set(id[:], buf)
 
 	// Increment the ID
 	incrementErr := k.incrementID(ctx)
@@ -75,7 +130,6 @@ func (k Keeper) setRequest(ctx sdk.Context, spends []byte, pays []byte, paysValu
 }
 
 func (k Keeper) setRequestState(ctx sdk.Context, requestID types.RequestID, active bool) sdk.Error {
-	store := k.getRequestStore(ctx)
 	request, err := k.getRequest(ctx, requestID)
 	if err != nil {
 		return err
@@ -83,11 +137,34 @@ func (k Keeper) setRequestState(ctx sdk.Context, requestID types.RequestID, acti
 
 	request.ActiveState = active
 
-	buf, marshalErr := json.Marshal(request)
-	if marshalErr != nil {
-		return types.ErrMarshalJSON(types.DefaultCodespace)
+	return k.storeRequest(ctx, requestID, request)
+}
+
+// CancelRequest closes an active request and refunds its escrow. Only the
+// request's creator may cancel it. Requests stored before creators were
+// recorded can not be cancelled
+func (k Keeper) CancelRequest(ctx sdk.Context, signer sdk.AccAddress, id types.RequestID) sdk.Error {
+	request, err := k.getRequest(ctx, id)
+	if err != nil {
+		return err
+	}
+	if request.Creator.Empty() || !request.Creator.Equals(signer) {
+		return types.ErrNotRequestOwner(types.DefaultCodespace, signer, id)
+	}
+	if !request.ActiveState {
+		return types.ErrClosedRequest(types.DefaultCodespace)
+	}
+
+	err = k.refundEscrow(ctx, &request)
+	if err != nil {
+		return err
+	}
+	request.ActiveState = false
+	err = k.storeRequest(ctx, id, request)
+	if err != nil {
+		return err
 	}
-	store.Set(requestID[:], buf)
+	k.emitRequestCancelled(ctx, id, request.Creator)
 	return nil
 }
 
@@ -100,13 +177,32 @@ func (k Keeper) getRequest(ctx sdk.Context, id types.RequestID) (types.ProofRequ
 	}
 
 	buf := store.Get(id[:])
+	return k.decodeRequest(buf)
+}
 
-	var request types.ProofRequest
-	jsonErr := json.Unmarshal(buf, &request)
-	if jsonErr != nil {
-		return types.ProofRequest{}, types.ErrExternal(types.DefaultCodespace, jsonErr)
+// getAllRequests returns every request in the store, ordered by ID
+func (k Keeper) getAllRequests(ctx sdk.Context) ([]types.IdentifiedRequest, sdk.Error) {
+	store := k.getRequestStore(ctx)
+	iterator := sdk.KVStorePrefixIterator(store, nil)
+	defer iterator.Close()
+
+	requests := []types.IdentifiedRequest{}
+	for ; iterator.Valid(); iterator.Next() {
+		// The request store also holds the ID counter. Skip it
+		if len(iterator.Key()) != 8 {
+			continue
+		}
+		id, err := types.NewRequestID(iterator.Key())
+		if err != nil {
+			return nil, err
+		}
+		request, err := k.decodeRequest(iterator.Value())
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, types.IdentifiedRequest{ID: id, Request: request})
 	}
-	return request, nil
+	return requests, nil
 }
 
 // incrementID increments the id used to store a request,
@@ -128,6 +224,12 @@ func (k Keeper) incrementID(ctx sdk.Context) sdk.Error {
 	return nil
 }
 
+// setNextID overwrites the ID that will be used for the next request
+func (k Keeper) setNextID(ctx sdk.Context, id types.RequestID) {
+	store := k.getRequestStore(ctx)
+	store.Set([]byte(types.RequestIDTag), id[:])
+}
+
 // getNextID retrieves the ID.  The ID is incremented after storing a request,
 // so this returns the next ID to be used.
 func (k Keeper) getNextID(ctx sdk.Context) (types.RequestID, sdk.Error) {
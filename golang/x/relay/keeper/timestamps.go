@@ -0,0 +1,76 @@
+package keeper
+
+import (
+	"sort"
+
+	"github.com/summa-tx/bitcoin-spv/golang/btcspv"
+	"github.com/summa-tx/relays/golang/x/relay/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// medianTimeSpan is the number of blocks in the median time past
+	medianTimeSpan = 11
+	// maxFutureBlockTime is how far, in seconds, a header may be ahead of
+	// the current block time. This matches Bitcoin Core's MAX_FUTURE_BLOCK_TIME
+	maxFutureBlockTime = 2 * 60 * 60
+	// maxTimewarp is how far, in seconds, the first block of a retarget
+	// period may be timestamped before its parent under BIP94. This matches
+	// Bitcoin Core's MAX_TIMEWARP
+	maxTimewarp = 600
+)
+
+// getTimestampWindow returns the timestamps of a header and up to 10 of its
+// ancestors, oldest first
+func (k Keeper) getTimestampWindow(ctx sdk.Context, header types.BitcoinHeader) []uint {
+	window := make([]uint, medianTimeSpan)
+	current := header
+	i := len(window) - 1
+	for ; i >= 0; i-- {
+		window[i] = btcspv.ExtractTimestamp(current.Raw)
+		if i == 0 {
+			break
+		}
+		prev, err := k.GetHeader(ctx, current.PrevHash)
+		if err != nil {
+			break
+		}
+		current = prev
+	}
+	return window[i:]
+}
+
+// medianTime returns the median of a list of timestamps
+func medianTime(timestamps []uint) uint {
+	sorted := make([]uint, len(timestamps))
+	copy(sorted, timestamps)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[len(sorted)/2]
+}
+
+// validateTimestamps checks that each header is later than the median of the
+// 11 blocks before it, and no more than 2 hours ahead of now. window holds
+// the timestamps of the blocks before headers, oldest first. The median rule
+// is only enforced once 11 previous timestamps are known, so that a relay
+// started from an arbitrary genesis can still accept its first headers
+func validateTimestamps(window []uint, headers []types.BitcoinHeader, now int64) sdk.Error {
+	for _, header := range headers {
+		timestamp := btcspv.ExtractTimestamp(header.Raw)
+
+		if len(window) >= medianTimeSpan {
+			window = window[len(window)-medianTimeSpan:]
+			mtp := medianTime(window)
+			if timestamp <= mtp {
+				return types.ErrTimestampTooEarly(types.DefaultCodespace, header.Raw, timestamp, mtp)
+			}
+		}
+
+		if int64(timestamp) > now+maxFutureBlockTime {
+			return types.ErrTimestampTooFarInFuture(types.DefaultCodespace, header.Raw, timestamp, maxFutureBlockTime, now)
+		}
+
+		window = append(window, timestamp)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/summa-tx/bitcoin-spv/golang/btcspv"
+	"github.com/summa-tx/relays/golang/x/relay/types"
+)
+
+func (s *KeeperSuite) TestMedianTime() {
+	s.Equal(uint(5), medianTime([]uint{9, 1, 5}))
+	s.Equal(uint(6), medianTime([]uint{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}))
+	s.Equal(uint(7), medianTime([]uint{11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1, 12, 13}))
+}
+
+func (s *KeeperSuite) TestValidateTimestamps() {
+	params := types.RegtestParams()
+	genesis := params.GenesisHeader
+	const bits = 0x207fffff
+	now := int64(1600000000)
+
+	// 11 blocks with increasing timestamps. The median is the 6th
+	window := []uint{}
+	for i := uint(0); i < medianTimeSpan; i++ {
+		window = append(window, 1500000000+i*600)
+	}
+	mtp := window[5]
+
+	// after the median is fine, even if before the previous block
+	ok := mineHeader(genesis, uint32(mtp+1), bits)
+	s.SDKNil(validateTimestamps(window, []types.BitcoinHeader{ok}, now))
+
+	// at or before the median is not
+	early := mineHeader(genesis, uint32(mtp), bits)
+	err := validateTimestamps(window, []types.BitcoinHeader{early}, now)
+	s.Equal(sdk.CodeType(types.TimestampTooEarly), err.Code())
+
+	// the median is not enforced until 11 timestamps are known
+	s.SDKNil(validateTimestamps(window[1:], []types.BitcoinHeader{early}, now))
+
+	// headers in the chain extend the window, moving the median to ok
+	next := mineHeader(ok, uint32(mtp+1), bits)
+	err = validateTimestamps(window, []types.BitcoinHeader{ok, next}, now)
+	s.Equal(sdk.CodeType(types.TimestampTooEarly), err.Code())
+
+	// up to 2 hours in the future is fine
+	future := mineHeader(genesis, uint32(now+maxFutureBlockTime), bits)
+	s.SDKNil(validateTimestamps(window, []types.BitcoinHeader{future}, now))
+	future = mineHeader(genesis, uint32(now+maxFutureBlockTime+1), bits)
+	err = validateTimestamps(window, []types.BitcoinHeader{future}, now)
+	s.Equal(sdk.CodeType(types.TimestampTooFarInFuture), err.Code())
+}
+
+func (s *KeeperSuite) TestGetTimestampWindow() {
+	tv := s.Fixtures.ChainTestCases.IsMostRecentCA
+	pre := tv.PreRetargetChain
+
+	err := s.Keeper.SetGenesisState(s.Context, tv.Genesis, tv.OldPeriodStart)
+	s.SDKNil(err)
+	err = s.Keeper.IngestHeaderChain(s.Context, pre)
+	s.SDKNil(err)
+
+	// stops at the bottom of the relay
+	window := s.Keeper.getTimestampWindow(s.Context, pre[1])
+	s.Equal(3, len(window))
+
+	// holds the header and up to 10 ancestors, oldest first
+	tip := pre[len(pre)-1]
+	window = s.Keeper.getTimestampWindow(s.Context, tip)
+	expected := len(pre) + 1
+	if expected > medianTimeSpan {
+		expected = medianTimeSpan
+	}
+	s.Equal(expected, len(window))
+	s.Equal(btcspv.ExtractTimestamp(tip.Raw), window[len(window)-1])
+}
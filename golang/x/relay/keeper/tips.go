@@ -0,0 +1,163 @@
+package keeper
+
+import (
+	"encoding/binary"
+	"sort"
+
+	"github.com/summa-tx/bitcoin-spv/golang/btcspv"
+	"github.com/summa-tx/relays/golang/x/relay/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// getTipStore returns the store of every header with no known children,
+// keyed by digest. The value is the tip's height
+func (k Keeper) getTipStore(ctx sdk.Context) sdk.KVStore {
+	return k.getPrefixStore(ctx, types.TipStorePrefix)
+}
+
+// setTip records a header as a chain tip
+func (k Keeper) setTip(ctx sdk.Context, digestLE types.Hash256Digest, height uint32) {
+	store := k.getTipStore(ctx)
+	store.Set(digestLE[:], heightKey(height))
+}
+
+// deleteTip removes a header from the chain tips
+func (k Keeper) deleteTip(ctx sdk.Context, digestLE types.Hash256Digest) {
+	store := k.getTipStore(ctx)
+	store.Delete(digestLE[:])
+}
+
+// isTip checks whether a header is a chain tip
+func (k Keeper) isTip(ctx sdk.Context, digestLE types.Hash256Digest) bool {
+	store := k.getTipStore(ctx)
+	return store.Has(digestLE[:])
+}
+
+// getTipDigests returns the digest of every chain tip, ordered by LE digest
+func (k Keeper) getTipDigests(ctx sdk.Context) []types.Hash256Digest {
+	store := k.getTipStore(ctx)
+	iterator := sdk.KVStorePrefixIterator(store, nil)
+	defer iterator.Close()
+
+	digests := []types.Hash256Digest{}
+	for ; iterator.Valid(); iterator.Next() {
+		// Can only fail if data store is corrupt
+		digest, _ := btcspv.NewHash256Digest(iterator.Key())
+		digests = append(digests, digest)
+	}
+	return digests
+}
+
+// getTips returns the digest and height of every chain tip, ordered by LE
+// digest
+func (k Keeper) getTips(ctx sdk.Context) []types.HeightDigest {
+	store := k.getTipStore(ctx)
+	iterator := sdk.KVStorePrefixIterator(store, nil)
+	defer iterator.Close()
+
+	tips := []types.HeightDigest{}
+	for ; iterator.Valid(); iterator.Next() {
+		// Can only fail if data store is corrupt
+		digest, _ := btcspv.NewHash256Digest(iterator.Key())
+		tips = append(tips, types.HeightDigest{
+			Height: binary.BigEndian.Uint32(iterator.Value()),
+			Digest: digest,
+		})
+	}
+	return tips
+}
+
+// updateTips moves the tip set after a chain of headers is ingested on top
+// of anchor. The anchor and any ingested header that was a tip now have
+// children. The last header becomes a tip unless it was already known, in
+// which case its status is unchanged
+func (k Keeper) updateTips(ctx sdk.Context, anchor types.BitcoinHeader, headers []types.BitcoinHeader, lastKnown bool) {
+	k.deleteTip(ctx, anchor.Hash)
+	for _, header := range headers[:len(headers)-1] {
+		k.deleteTip(ctx, header.Hash)
+	}
+
+	last := headers[len(headers)-1]
+	if !lastKnown {
+		k.setTip(ctx, last.Hash, last.Height)
+	}
+}
+
+// rebuildTips recomputes the tip set from the links. Every linked header
+// with no children is a tip, as is the relay genesis if nothing builds on it
+func (k Keeper) rebuildTips(ctx sdk.Context) sdk.Error {
+	for _, digest := range k.getTipDigests(ctx) {
+		k.deleteTip(ctx, digest)
+	}
+
+	relayGenesis, err := k.GetRelayGenesis(ctx)
+	if err != nil {
+		return err
+	}
+
+	links := k.getAllLinks(ctx)
+	parents := make(map[types.Hash256Digest]bool)
+	for _, link := range links {
+		parents[link.Parent] = true
+	}
+
+	candidates := []types.Hash256Digest{relayGenesis}
+	for _, link := range links {
+		candidates = append(candidates, link.Digest)
+	}
+	for _, digest := range candidates {
+		if parents[digest] {
+			continue
+		}
+		header, err := k.GetHeader(ctx, digest)
+		if err != nil {
+			return err
+		}
+		k.setTip(ctx, header.Hash, header.Height)
+	}
+	return nil
+}
+
+// GetChainTips returns every known chain tip with its fork point relative to
+// the best chain, ordered from the highest tip to the lowest
+func (k Keeper) GetChainTips(ctx sdk.Context) ([]types.ChainTip, sdk.Error) {
+	bestKnown, err := k.GetBestKnownDigest(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tips := []types.ChainTip{}
+	for _, digest := range k.getTipDigests(ctx) {
+		header, err := k.GetHeader(ctx, digest)
+		if err != nil {
+			return nil, err
+		}
+
+		tip := types.ChainTip{
+			Digest:    digest,
+			Height:    header.Height,
+			ChainWork: k.getChainWork(ctx, digest),
+			Status:    types.TipStatusValidFork,
+		}
+		if digest == bestKnown {
+			tip.Status = types.TipStatusActive
+		}
+
+		// Forks that leave the best chain below the relay genesis have no
+		// fork point
+		forkPoint, found := k.findBestChainAncestor(ctx, header, header.Height)
+		if found {
+			tip.ForkPoint = forkPoint.Hash
+			tip.ForkHeight = forkPoint.Height
+			tip.BranchLen = header.Height - forkPoint.Height
+		}
+
+		tips = append(tips, tip)
+	}
+
+	sort.SliceStable(tips, func(i, j int) bool {
+		return tips[i].Height > tips[j].Height
+	})
+	return tips, nil
+}
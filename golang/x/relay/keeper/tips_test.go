@@ -0,0 +1,76 @@
+package keeper
+
+import (
+	"github.com/summa-tx/relays/golang/x/relay/types"
+)
+
+func (s *KeeperSuite) TestChainTips() {
+	tv := s.Fixtures.ChainTestCases.IsMostRecentCA
+	pre := tv.PreRetargetChain
+	post := tv.PostRetargetChain
+	var postWithOrphan []types.BitcoinHeader
+	postWithOrphan = append(postWithOrphan, post[:len(post)-2]...)
+	postWithOrphan = append(postWithOrphan, tv.Orphan)
+	tip := post[len(post)-1]
+
+	err := s.Keeper.SetGenesisState(s.Context, tv.Genesis, tv.OldPeriodStart)
+	s.SDKNil(err)
+
+	// the genesis is the only tip
+	tips, err := s.Keeper.GetChainTips(s.Context)
+	s.SDKNil(err)
+	s.Equal(1, len(tips))
+	s.Equal(tv.Genesis.Hash, tips[0].Digest)
+	s.Equal(types.TipStatusActive, tips[0].Status)
+	s.Equal(uint32(0), tips[0].BranchLen)
+
+	err = s.Keeper.IngestHeaderChain(s.Context, pre)
+	s.SDKNil(err)
+	err = s.Keeper.IngestDifficultyChange(s.Context, tv.OldPeriodStart.Hash, post)
+	s.SDKNil(err)
+	err = s.Keeper.IngestDifficultyChange(s.Context, tv.OldPeriodStart.Hash, postWithOrphan)
+	s.SDKNil(err)
+	s.False(s.Keeper.isTip(s.Context, tv.Genesis.Hash))
+	s.False(s.Keeper.isTip(s.Context, pre[len(pre)-1].Hash))
+
+	// re-ingesting known headers does not make them tips
+	err = s.Keeper.IngestHeaderChain(s.Context, pre)
+	s.SDKNil(err)
+	s.False(s.Keeper.isTip(s.Context, pre[len(pre)-1].Hash))
+
+	// both branches fork from the genesis, which is still the best known
+	tips, err = s.Keeper.GetChainTips(s.Context)
+	s.SDKNil(err)
+	s.Equal(2, len(tips))
+	s.Equal(tip.Hash, tips[0].Digest)
+	s.Equal(tip.Height, tips[0].Height)
+	s.Equal(s.Keeper.getChainWork(s.Context, tip.Hash), tips[0].ChainWork)
+	s.Equal(tv.Orphan.Hash, tips[1].Digest)
+	for _, t := range tips {
+		s.Equal(types.TipStatusValidFork, t.Status)
+		s.Equal(tv.Genesis.Hash, t.ForkPoint)
+		s.Equal(tv.Genesis.Height, t.ForkHeight)
+		s.Equal(t.Height-tv.Genesis.Height, t.BranchLen)
+	}
+
+	// marking the tip as heaviest moves the orphan's fork point
+	err = s.Keeper.MarkNewHeaviest(s.Context, tv.Genesis.Hash, tv.Genesis.Raw, tip.Raw, 20)
+	s.SDKNil(err)
+	tips, err = s.Keeper.GetChainTips(s.Context)
+	s.SDKNil(err)
+	s.Equal(types.TipStatusActive, tips[0].Status)
+	s.Equal(tip.Hash, tips[0].ForkPoint)
+	s.Equal(uint32(0), tips[0].BranchLen)
+	s.Equal(types.TipStatusValidFork, tips[1].Status)
+	s.Equal(post[len(post)-3].Hash, tips[1].ForkPoint)
+	s.Equal(uint32(1), tips[1].BranchLen)
+
+	// the tips can be rebuilt from the links
+	store := s.Keeper.getTipStore(s.Context)
+	store.Delete(tip.Hash[:])
+	store.Set(tv.Genesis.Hash[:], heightKey(tv.Genesis.Height))
+	s.SDKNil(s.Keeper.rebuildTips(s.Context))
+	rebuilt, err := s.Keeper.GetChainTips(s.Context)
+	s.SDKNil(err)
+	s.Equal(tips, rebuilt)
+}
@@ -41,11 +41,21 @@ func (k Keeper) validateProof(ctx sdk.Context, proof types.SPVProof) sdk.Error {
 	if lcaErr != nil {
 		return lcaErr
 	}
-	isAncestor := k.IsAncestor(ctx, proof.ConfirmingHeader.Hash, lca, 240)
+	isAncestor := k.IsAncestor(ctx, proof.ConfirmingHeader.Hash, lca, k.GetParams(ctx).ProofAncestorLimit)
 	if !isAncestor {
 		return types.ErrNotAncestor(types.DefaultCodespace, proof.ConfirmingHeader.Hash)
 	}
 
+	// Headers above the LCA may be on a side branch. Check the stored header,
+	// as the proof's height is not validated
+	confirming, getErr := k.GetHeader(ctx, proof.ConfirmingHeader.Hash)
+	if getErr != nil {
+		return getErr
+	}
+	if !k.isInBestChain(ctx, confirming) {
+		return types.ErrNotInBestChain(types.DefaultCodespace, confirming.Hash)
+	}
+
 	return nil
 }
 
@@ -56,23 +66,46 @@ func (k Keeper) checkRequestsFilled(ctx sdk.Context, filledRequests types.Filled
 		return nil, err
 	}
 
-	confs, confsErr := k.getConfs(ctx, filledRequests.Proof.ConfirmingHeader)
+	// The proof's header has been checked against the store, but its height
+	// has not. Use the stored header for confirmations and expiry
+	confirming, getErr := k.GetHeader(ctx, filledRequests.Proof.ConfirmingHeader.Hash)
+	if getErr != nil {
+		return nil, getErr
+	}
+
+	confs, confsErr := k.getConfs(ctx, confirming)
 	if confsErr != nil {
 		return nil, confsErr
 	}
 
 	var filled []types.ProofRequest
+	txid := filledRequests.Proof.TxID
+	seen := make(map[types.RequestID]bool)
 
 	for i := range filledRequests.Filled {
 		// get request
-		request, getErr := k.getRequest(ctx, filledRequests.Filled[i].ID)
+		id := filledRequests.Filled[i].ID
+		request, getErr := k.getRequest(ctx, id)
 		if getErr != nil {
 			return nil, getErr
 		}
+		// a transaction fills each request at most once
+		if seen[id] || k.hasRequestFill(ctx, id, txid) {
+			return nil, types.ErrAlreadyProven(types.DefaultCodespace, txid, id)
+		}
+		seen[id] = true
+		// check the Bitcoin expiry
+		if request.BTCExpiryHeight != 0 && confirming.Height > request.BTCExpiryHeight {
+			return nil, types.ErrProofPastExpiry(types.DefaultCodespace, confirming.Height, request.BTCExpiryHeight, id)
+		}
 		// check confirmations
 		if confs < uint32(request.NumConfs) {
 			return nil, types.ErrNotEnoughConfs(types.DefaultCodespace, filledRequests.Filled[i].ID)
 		}
+		// check the escrow can pay the prover
+		if !canPayBounty(request) {
+			return nil, types.ErrInsufficientEscrow(types.DefaultCodespace, request.Escrow.String(), request.Bounty.String(), id)
+		}
 
 		// check request
 		err := k.checkRequests(
@@ -2,6 +2,7 @@ package keeper
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/summa-tx/bitcoin-spv/golang/btcspv"
 	"github.com/summa-tx/relays/golang/x/relay/types"
 )
 
@@ -48,6 +49,7 @@ func (s *KeeperSuite) TestValidateProof() {
 		s.Keeper.setLastReorgLCA(s.Context, proofCases[i].LCA)
 		s.Keeper.ingestHeader(s.Context, proofCases[i].Proof.ConfirmingHeader)
 		s.Keeper.setLink(s.Context, proofCases[i].Proof.ConfirmingHeader)
+		s.Keeper.setHeightDigest(s.Context, proofCases[i].Proof.ConfirmingHeader.Height, proofCases[i].Proof.ConfirmingHeader.Hash)
 
 		if proofCases[i].Error != 0 {
 			err := s.Keeper.validateProof(s.Context, proofCases[i].Proof)
@@ -59,6 +61,29 @@ func (s *KeeperSuite) TestValidateProof() {
 	}
 }
 
+func (s *KeeperSuite) TestValidateProofSideBranch() {
+	_, main := s.initPruneTest(4)
+	s.Keeper.setLastReorgLCA(s.Context, main[1].Hash)
+
+	// a block that only holds the proof's transaction
+	proof := s.Fixtures.ValidatorTestCases.ValidateProof[0].Proof
+	timestamp := uint32(btcspv.ExtractTimestamp(main[1].Raw)) + 601
+	side := mineHeaderWithRoot(main[1], proof.TxID, timestamp, 0x207fffff)
+	s.SDKNil(s.Keeper.IngestHeaderChain(s.Context, []types.BitcoinHeader{side}))
+	proof.ConfirmingHeader = side
+	proof.IntermediateNodes = []byte{}
+	proof.Index = 0
+
+	// errors if the confirming header is on a side branch above the LCA
+	s.True(s.Keeper.IsAncestor(s.Context, side.Hash, main[1].Hash, 1))
+	err := s.Keeper.validateProof(s.Context, proof)
+	s.Equal(sdk.CodeType(types.NotInBestChain), err.Code())
+
+	// succeeds once the branch becomes the best chain
+	s.SDKNil(s.Keeper.IngestHeaderChain(s.Context, mineChain(side, 3, 600)))
+	s.SDKNil(s.Keeper.validateProof(s.Context, proof))
+}
+
 func (s *KeeperSuite) TestCheckRequestsFilled() {
 	tc := s.Fixtures.ValidatorTestCases.CheckRequestsFilled
 	validProof := s.Fixtures.ValidatorTestCases.ValidateProof[0]
@@ -66,8 +91,9 @@ func (s *KeeperSuite) TestCheckRequestsFilled() {
 	s.Keeper.setLastReorgLCA(s.Context, validProof.LCA)
 	s.Keeper.ingestHeader(s.Context, validProof.Proof.ConfirmingHeader)
 	s.Keeper.setLink(s.Context, validProof.Proof.ConfirmingHeader)
+	s.Keeper.setHeightDigest(s.Context, validProof.Proof.ConfirmingHeader.Height, validProof.Proof.ConfirmingHeader.Hash)
 	s.Keeper.ingestHeader(s.Context, validProof.BestKnown)
-	requestErr := s.Keeper.setRequest(s.Context, []byte{}, []byte{}, 0, 4, types.Local, nil)
+	requestErr := s.Keeper.setRequest(s.Context, nil, []byte{}, []byte{}, 0, 4, types.Local, nil, types.RequestOptions{})
 	s.Nil(requestErr)
 
 	// errors if getConfs fails
@@ -98,7 +124,7 @@ func (s *KeeperSuite) TestCheckRequestsFilled() {
 	}
 
 	// errors if number of confirmations is less than the number of confirmations on the request
-	requestErr = s.Keeper.setRequest(s.Context, []byte{0}, []byte{0}, 0, 5, types.Local, nil)
+	requestErr = s.Keeper.setRequest(s.Context, nil, []byte{0}, []byte{0}, 0, 5, types.Local, nil, types.RequestOptions{})
 	s.Nil(requestErr)
 
 	copiedRequest := tc[0].FilledRequests
@@ -106,3 +132,81 @@ func (s *KeeperSuite) TestCheckRequestsFilled() {
 	_, err = s.Keeper.checkRequestsFilled(s.Context, copiedRequest)
 	s.Equal(sdk.CodeType(types.NotEnoughConfs), err.Code())
 }
+
+func (s *KeeperSuite) TestCheckRequestsFilledReplay() {
+	filled := s.Fixtures.ValidatorTestCases.CheckRequestsFilled[0].FilledRequests
+	validProof := s.Fixtures.ValidatorTestCases.ValidateProof[0]
+
+	s.Keeper.setLastReorgLCA(s.Context, validProof.LCA)
+	s.Keeper.ingestHeader(s.Context, validProof.Proof.ConfirmingHeader)
+	s.Keeper.setLink(s.Context, validProof.Proof.ConfirmingHeader)
+	s.Keeper.setHeightDigest(s.Context, validProof.Proof.ConfirmingHeader.Height, validProof.Proof.ConfirmingHeader.Hash)
+	s.Keeper.ingestHeader(s.Context, validProof.BestKnown)
+	s.Keeper.setBestKnownDigest(s.Context, validProof.BestKnown.Hash)
+	requestErr := s.Keeper.setRequest(s.Context, nil, []byte{}, []byte{}, 0, 4, types.Local, nil, types.RequestOptions{MaxFills: 2})
+	s.SDKNil(requestErr)
+
+	// copy the fill info, as other tests modify the fixture
+	info := filled.Filled[0]
+	info.ID = types.RequestID{}
+	filled.Filled = []types.FilledRequestInfo{info}
+
+	// errors if a request is filled twice by one proof
+	doubled := filled
+	doubled.Filled = []types.FilledRequestInfo{info, info}
+	_, err := s.Keeper.checkRequestsFilled(s.Context, doubled)
+	s.Equal(sdk.CodeType(types.AlreadyProven), err.Code())
+
+	_, err = s.Keeper.checkRequestsFilled(s.Context, filled)
+	s.SDKNil(err)
+	s.SDKNil(s.Keeper.fillRequests(s.Context, nil, filled))
+
+	// errors if the transaction already filled the request, though it is open
+	request, err := s.Keeper.getRequest(s.Context, filled.Filled[0].ID)
+	s.SDKNil(err)
+	s.True(request.ActiveState)
+	_, err = s.Keeper.checkRequestsFilled(s.Context, filled)
+	s.Equal(sdk.CodeType(types.AlreadyProven), err.Code())
+}
+
+func (s *KeeperSuite) TestCheckRequestsFilledExpiry() {
+	filled := s.Fixtures.ValidatorTestCases.CheckRequestsFilled[0].FilledRequests
+	validProof := s.Fixtures.ValidatorTestCases.ValidateProof[0]
+	height := validProof.Proof.ConfirmingHeader.Height
+
+	s.Keeper.setLastReorgLCA(s.Context, validProof.LCA)
+	s.Keeper.ingestHeader(s.Context, validProof.Proof.ConfirmingHeader)
+	s.Keeper.setLink(s.Context, validProof.Proof.ConfirmingHeader)
+	s.Keeper.setHeightDigest(s.Context, validProof.Proof.ConfirmingHeader.Height, validProof.Proof.ConfirmingHeader.Hash)
+	s.Keeper.ingestHeader(s.Context, validProof.BestKnown)
+	s.Keeper.setBestKnownDigest(s.Context, validProof.BestKnown.Hash)
+	s.SDKNil(s.Keeper.setRequest(s.Context, nil, []byte{}, []byte{}, 0, 4, types.Local, nil, types.RequestOptions{BTCExpiryHeight: height - 1}))
+	s.SDKNil(s.Keeper.setRequest(s.Context, nil, []byte{}, []byte{}, 0, 4, types.Local, nil, types.RequestOptions{BTCExpiryHeight: height}))
+
+	// copy the fill info, as other tests modify the fixture
+	info := filled.Filled[0]
+	info.ID = types.RequestID{}
+	filled.Filled = []types.FilledRequestInfo{info}
+
+	// errors if the proof is confirmed after the Bitcoin expiry height
+	_, err := s.Keeper.checkRequestsFilled(s.Context, filled)
+	s.Equal(sdk.CodeType(types.ProofPastExpiry), err.Code())
+
+	// proofs confirmed at the expiry height are accepted
+	filled.Filled[0].ID = types.RequestID{0, 0, 0, 0, 0, 0, 0, 1}
+	_, err = s.Keeper.checkRequestsFilled(s.Context, filled)
+	s.SDKNil(err)
+
+	// the stored height is used for confirmations and expiry, not the proof's
+	filled.Proof.ConfirmingHeader.Height = height + 3
+	_, err = s.Keeper.checkRequestsFilled(s.Context, filled)
+	s.SDKNil(err)
+
+	// errors if the escrow can not pay the bounty
+	request, err := s.Keeper.getRequest(s.Context, filled.Filled[0].ID)
+	s.SDKNil(err)
+	request.Bounty = sdk.NewCoins(sdk.NewInt64Coin("stake", 10))
+	s.SDKNil(s.Keeper.storeRequest(s.Context, filled.Filled[0].ID, request))
+	_, err = s.Keeper.checkRequestsFilled(s.Context, filled)
+	s.Equal(sdk.CodeType(types.InsufficientEscrow), err.Code())
+}
@@ -0,0 +1,128 @@
+package keeper
+
+import (
+	"math/big"
+
+	"github.com/summa-tx/bitcoin-spv/golang/btcspv"
+	"github.com/summa-tx/relays/golang/x/relay/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func (k Keeper) getChainWorkStore(ctx sdk.Context) sdk.KVStore {
+	return k.getPrefixStore(ctx, types.ChainWorkStorePrefix)
+}
+
+// calculateWork returns the expected number of hashes needed to produce a
+// header, as 2**256 / (target + 1). This matches Bitcoin Core's GetBlockProof
+func calculateWork(raw types.RawHeader) sdk.Uint {
+	target := btcspv.ExtractTarget(raw).BigInt()
+	target.Add(target, big.NewInt(1))
+
+	work := new(big.Int).Lsh(big.NewInt(1), 256)
+	work.Div(work, target)
+	return sdk.NewUintFromBigInt(work)
+}
+
+// setChainWork stores the accumulated work of the chain ending in a header
+func (k Keeper) setChainWork(ctx sdk.Context, digestLE types.Hash256Digest, work sdk.Uint) sdk.Error {
+	store := k.getChainWorkStore(ctx)
+
+	b, err := work.MarshalJSON()
+	if err != nil {
+		return types.ErrExternal(types.DefaultCodespace, err)
+	}
+
+	store.Set(digestLE[:], b)
+	return nil
+}
+
+// deleteChainWork removes the accumulated work of a pruned header
+func (k Keeper) deleteChainWork(ctx sdk.Context, digestLE types.Hash256Digest) {
+	store := k.getChainWorkStore(ctx)
+	store.Delete(digestLE[:])
+}
+
+// getChainWork gets the accumulated work of the chain ending in a header.
+// Headers stored without work (e.g. an anchor ingested directly) have zero
+func (k Keeper) getChainWork(ctx sdk.Context, digestLE types.Hash256Digest) sdk.Uint {
+	store := k.getChainWorkStore(ctx)
+	result := store.Get(digestLE[:])
+	if result == nil {
+		return sdk.ZeroUint()
+	}
+
+	var work sdk.Uint
+	// This will only fail if the store is corrupted
+	_ = work.UnmarshalJSON(result)
+
+	return work
+}
+
+// GetChainWork returns the accumulated work of the chain ending in a header,
+// counted from the relay genesis
+func (k Keeper) GetChainWork(ctx sdk.Context, digestLE types.Hash256Digest) (sdk.Uint, sdk.Error) {
+	if !k.HasHeader(ctx, digestLE) {
+		return sdk.Uint{}, types.ErrUnknownBlock(types.DefaultCodespace, "digest", digestLE)
+	}
+	return k.getChainWork(ctx, digestLE), nil
+}
+
+// rebuildChainWork recomputes the accumulated work of every header, walking
+// the links forward from the relay genesis
+func (k Keeper) rebuildChainWork(ctx sdk.Context) sdk.Error {
+	relayGenesis, err := k.GetRelayGenesis(ctx)
+	if err != nil {
+		return err
+	}
+	genesis, err := k.GetHeader(ctx, relayGenesis)
+	if err != nil {
+		return err
+	}
+
+	children := make(map[types.Hash256Digest][]types.Hash256Digest)
+	for _, link := range k.getAllLinks(ctx) {
+		children[link.Parent] = append(children[link.Parent], link.Digest)
+	}
+
+	work := calculateWork(genesis.Raw)
+	err = k.setChainWork(ctx, genesis.Hash, work)
+	if err != nil {
+		return err
+	}
+	queue := []types.Hash256Digest{genesis.Hash}
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+		parentWork := k.getChainWork(ctx, parent)
+		for _, digest := range children[parent] {
+			header, err := k.GetHeader(ctx, digest)
+			if err != nil {
+				return err
+			}
+			err = k.setChainWork(ctx, digest, parentWork.Add(calculateWork(header.Raw)))
+			if err != nil {
+				return err
+			}
+			queue = append(queue, digest)
+		}
+	}
+	return nil
+}
+
+// getAllChainWork returns the accumulated work of every header, ordered by LE digest
+func (k Keeper) getAllChainWork(ctx sdk.Context) []types.HeaderWork {
+	store := k.getChainWorkStore(ctx)
+	iterator := sdk.KVStorePrefixIterator(store, nil)
+	defer iterator.Close()
+
+	entries := []types.HeaderWork{}
+	for ; iterator.Valid(); iterator.Next() {
+		// Can only fail if data store is corrupt
+		digest, _ := btcspv.NewHash256Digest(iterator.Key())
+		var work sdk.Uint
+		_ = work.UnmarshalJSON(iterator.Value())
+		entries = append(entries, types.HeaderWork{Digest: digest, ChainWork: work})
+	}
+	return entries
+}
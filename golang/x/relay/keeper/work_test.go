@@ -0,0 +1,60 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/summa-tx/relays/golang/x/relay/types"
+)
+
+func (s *KeeperSuite) TestCalculateWork() {
+	// difficulty 1 header, nBits 0x1d00ffff
+	var raw types.RawHeader
+	copy(raw[72:76], []byte{0xff, 0xff, 0x00, 0x1d})
+	s.Equal(sdk.NewUint(0x100010001), calculateWork(raw))
+}
+
+func (s *KeeperSuite) TestChainWork() {
+	tv := s.Fixtures.ChainTestCases.IsMostRecentCA
+	pre := tv.PreRetargetChain
+	post := tv.PostRetargetChain
+
+	// errors if the header is unknown
+	_, err := s.Keeper.GetChainWork(s.Context, tv.Genesis.Hash)
+	s.Equal(sdk.CodeType(types.UnknownBlock), err.Code())
+
+	err = s.Keeper.SetGenesisState(s.Context, tv.Genesis, tv.OldPeriodStart)
+	s.SDKNil(err)
+
+	// genesis starts with its own work
+	work, err := s.Keeper.GetChainWork(s.Context, tv.Genesis.Hash)
+	s.SDKNil(err)
+	s.Equal(calculateWork(tv.Genesis.Raw), work)
+
+	err = s.Keeper.IngestHeaderChain(s.Context, pre)
+	s.SDKNil(err)
+	err = s.Keeper.IngestDifficultyChange(s.Context, tv.OldPeriodStart.Hash, post)
+	s.SDKNil(err)
+
+	// each header accumulates its parent's work
+	expected := calculateWork(tv.Genesis.Raw)
+	for _, h := range append(pre, post...) {
+		expected = expected.Add(calculateWork(h.Raw))
+		work, err = s.Keeper.GetChainWork(s.Context, h.Hash)
+		s.SDKNil(err)
+		s.Equal(expected, work)
+	}
+}
+
+func (s *KeeperSuite) TestRebuildChainWork() {
+	_, main := s.initPruneTest(4)
+	fork := mineChain(main[1], 2, 601)
+	s.SDKNil(s.Keeper.IngestHeaderChain(s.Context, fork))
+	expected := s.Keeper.getAllChainWork(s.Context)
+	s.Equal(7, len(expected))
+
+	// stores written before chainwork was added have none
+	s.clearPrefixStore(types.ChainWorkStorePrefix)
+	s.Equal(0, len(s.Keeper.getAllChainWork(s.Context)))
+
+	s.SDKNil(s.Keeper.rebuildChainWork(s.Context))
+	s.Equal(expected, s.Keeper.getAllChainWork(s.Context))
+}
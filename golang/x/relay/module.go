@@ -113,8 +113,21 @@ func (am AppModule) NewQuerierHandler() sdk.Querier {
 // BeginBlock is
 func (am AppModule) BeginBlock(_ sdk.Context, _ abci.RequestBeginBlock) {}
 
-// EndBlock is
-func (am AppModule) EndBlock(sdk.Context, abci.RequestEndBlock) []abci.ValidatorUpdate {
+// EndBlock writes back clamped params, connects or drops pooled orphan
+// chains, closes expired requests, and prunes stale forks and old history
+// from the relay store
+func (am AppModule) EndBlock(ctx sdk.Context, _ abci.RequestEndBlock) []abci.ValidatorUpdate {
+	am.keeper.ClampParams(ctx)
+	am.keeper.ConnectOrphans(ctx)
+	am.keeper.ExpireOrphans(ctx)
+	err := am.keeper.ExpireRequests(ctx)
+	if err != nil {
+		panic("Could not expire relay requests! " + err.Error())
+	}
+	err = am.keeper.Prune(ctx)
+	if err != nil {
+		panic("Could not prune relay store! " + err.Error())
+	}
 	return []abci.ValidatorUpdate{}
 }
 
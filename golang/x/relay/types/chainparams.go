@@ -0,0 +1,148 @@
+package types
+
+import (
+	"encoding/hex"
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/summa-tx/bitcoin-spv/golang/btcspv"
+)
+
+// Network identifies a Bitcoin network
+type Network string
+
+const (
+	// Mainnet is the Bitcoin main network
+	Mainnet Network = "mainnet"
+	// Testnet3 is the third Bitcoin test network
+	Testnet3 Network = "testnet3"
+	// Testnet4 is the fourth Bitcoin test network (BIP94)
+	Testnet4 Network = "testnet4"
+	// Signet is the default Bitcoin signet (BIP325)
+	Signet Network = "signet"
+	// Regtest is the Bitcoin regression test network
+	Regtest Network = "regtest"
+)
+
+// ChainParams holds the consensus parameters of the Bitcoin network the
+// relay follows
+type ChainParams struct {
+	Network Network `json:"network"`
+	// Number of blocks between difficulty retargets
+	RetargetInterval uint32 `json:"retargetInterval"`
+	// Expected number of seconds between blocks
+	TargetSpacing uint32 `json:"targetSpacing"`
+	// Easiest target allowed on the network
+	PowLimit sdk.Uint `json:"powLimit"`
+	// Allow a block at PowLimit if it is 2 * TargetSpacing after its parent
+	AllowMinDifficultyBlocks bool `json:"allowMinDifficultyBlocks"`
+	// Never change the target
+	NoRetargeting bool `json:"noRetargeting"`
+	// Retarget from the first block of the period rather than the last (BIP94)
+	EnforceBIP94 bool `json:"enforceBIP94"`
+	// The first block of the network
+	GenesisHeader BitcoinHeader `json:"genesisHeader"`
+}
+
+// TargetTimespan returns the expected number of seconds in a retarget period
+func (p ChainParams) TargetTimespan() uint64 {
+	return uint64(p.RetargetInterval) * uint64(p.TargetSpacing)
+}
+
+func mustUintFromHex(s string) sdk.Uint {
+	i, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("bad hex integer " + s)
+	}
+	return sdk.NewUintFromBigInt(i)
+}
+
+func mustHeaderFromHex(s string) BitcoinHeader {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	raw, err := btcspv.NewRawHeader(b)
+	if err != nil {
+		panic(err)
+	}
+	return btcspv.HeaderFromRaw(raw, 0)
+}
+
+// MainnetParams returns the chain parameters of the Bitcoin main network
+func MainnetParams() ChainParams {
+	return ChainParams{
+		Network:          Mainnet,
+		RetargetInterval: 2016,
+		TargetSpacing:    600,
+		PowLimit:         mustUintFromHex("00000000ffffffffffffffffffffffffffffffffffffffffffffffffffffffff"),
+		GenesisHeader:    mustHeaderFromHex("0100000000000000000000000000000000000000000000000000000000000000000000003ba3edfd7a7b12b27ac72c3e67768f617fc81bc3888a51323a9fb8aa4b1e5e4a29ab5f49ffff001d1dac2b7c"),
+	}
+}
+
+// Testnet3Params returns the chain parameters of Bitcoin testnet3
+func Testnet3Params() ChainParams {
+	return ChainParams{
+		Network:                  Testnet3,
+		RetargetInterval:         2016,
+		TargetSpacing:            600,
+		PowLimit:                 mustUintFromHex("00000000ffffffffffffffffffffffffffffffffffffffffffffffffffffffff"),
+		AllowMinDifficultyBlocks: true,
+		GenesisHeader:            mustHeaderFromHex("0100000000000000000000000000000000000000000000000000000000000000000000003ba3edfd7a7b12b27ac72c3e67768f617fc81bc3888a51323a9fb8aa4b1e5e4adae5494dffff001d1aa4ae18"),
+	}
+}
+
+// Testnet4Params returns the chain parameters of Bitcoin testnet4
+func Testnet4Params() ChainParams {
+	return ChainParams{
+		Network:                  Testnet4,
+		RetargetInterval:         2016,
+		TargetSpacing:            600,
+		PowLimit:                 mustUintFromHex("00000000ffffffffffffffffffffffffffffffffffffffffffffffffffffffff"),
+		AllowMinDifficultyBlocks: true,
+		EnforceBIP94:             true,
+		GenesisHeader:            mustHeaderFromHex("0100000000000000000000000000000000000000000000000000000000000000000000004e7b2b9128fe0291db0693af2ae418b767e657cd407e80cb1434221eaea7a07a046f3566ffff001dbb0c7817"),
+	}
+}
+
+// SignetParams returns the chain parameters of the default Bitcoin signet
+func SignetParams() ChainParams {
+	return ChainParams{
+		Network:          Signet,
+		RetargetInterval: 2016,
+		TargetSpacing:    600,
+		PowLimit:         mustUintFromHex("00000377ae000000000000000000000000000000000000000000000000000000"),
+		GenesisHeader:    mustHeaderFromHex("0100000000000000000000000000000000000000000000000000000000000000000000003ba3edfd7a7b12b27ac72c3e67768f617fc81bc3888a51323a9fb8aa4b1e5e4a008f4d5fae77031e8ad22203"),
+	}
+}
+
+// RegtestParams returns the chain parameters of Bitcoin regtest
+func RegtestParams() ChainParams {
+	return ChainParams{
+		Network:                  Regtest,
+		RetargetInterval:         2016,
+		TargetSpacing:            600,
+		PowLimit:                 mustUintFromHex("7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"),
+		AllowMinDifficultyBlocks: true,
+		NoRetargeting:            true,
+		GenesisHeader:            mustHeaderFromHex("0100000000000000000000000000000000000000000000000000000000000000000000003ba3edfd7a7b12b27ac72c3e67768f617fc81bc3888a51323a9fb8aa4b1e5e4adae5494dffff7f2002000000"),
+	}
+}
+
+// ChainParamsForNetwork returns the chain parameters of a known network
+func ChainParamsForNetwork(network Network) (ChainParams, sdk.Error) {
+	switch network {
+	case Mainnet:
+		return MainnetParams(), nil
+	case Testnet3:
+		return Testnet3Params(), nil
+	case Testnet4:
+		return Testnet4Params(), nil
+	case Signet:
+		return SignetParams(), nil
+	case Regtest:
+		return RegtestParams(), nil
+	default:
+		return ChainParams{}, ErrUnknownNetwork(DefaultCodespace, network)
+	}
+}
@@ -0,0 +1,37 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainParamsForNetwork(t *testing.T) {
+	// genesis digests are LE
+	genesisDigests := map[Network]string{
+		Mainnet:  "6fe28c0ab6f1b372c1a6a246ae63f74f931e8365e15a089c68d6190000000000",
+		Testnet3: "43497fd7f826957108f4a30fd9cec3aeba79972084e90ead01ea330900000000",
+		Testnet4: "43f08bdab050e35b567c864b91f47f50ae725ae2de53bcfbbaf284da00000000",
+		Signet:   "f61eee3b63a380a477a063af32b2bbc97c9ff9f01f2c4225e973988108000000",
+		Regtest:  "06226e46111a0b59caaf126043eb5bbf28c34f3a5e332a1fc7b2b73cf188910f",
+	}
+
+	for network, digestHex := range genesisDigests {
+		params, err := ChainParamsForNetwork(network)
+		assert.Nil(t, err)
+		assert.Equal(t, network, params.Network)
+		assert.Equal(t, uint64(1209600), params.TargetTimespan())
+
+		digest, err := Hash256DigestFromHex(digestHex)
+		assert.Nil(t, err)
+		assert.Equal(t, digest, params.GenesisHeader.Hash)
+
+		// the genesis block meets its own network's PoW limit
+		_, validateErr := params.GenesisHeader.Validate()
+		assert.Nil(t, validateErr)
+	}
+
+	_, err := ChainParamsForNetwork("testnet2")
+	assert.Equal(t, sdk.CodeType(UnknownNetwork), err.Code())
+}
@@ -18,4 +18,6 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgMarkNewHeaviest{}, "relay/MarkNewHeaviest", nil)
 	cdc.RegisterConcrete(MsgNewRequest{}, "relay/NewRequest", nil)
 	cdc.RegisterConcrete(MsgProvideProof{}, "relay/ProvideProof", nil)
+	cdc.RegisterConcrete(MsgCancelRequest{}, "relay/CancelRequest", nil)
+	cdc.RegisterConcrete(CheckpointProposal{}, "relay/CheckpointProposal", nil)
 }
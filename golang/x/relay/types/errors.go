@@ -61,6 +61,26 @@ const (
 	// BadOffsetMessage is the corresponding message
 	BadOffsetMessage = "Reached bottom of relay chain: block with digest %x has no link"
 
+	// UnknownHeight occurs when no best chain block is known at a height
+	UnknownHeight sdk.CodeType = 111
+	// UnknownHeightMessage is the corresponding message
+	UnknownHeightMessage = "No block known at height %d on the best chain"
+
+	// UnknownNetwork occurs when no chain parameters exist for a network name
+	UnknownNetwork sdk.CodeType = 112
+	// UnknownNetworkMessage is the corresponding message
+	UnknownNetworkMessage = "Unknown Bitcoin network %q. Expected mainnet, testnet3, testnet4, signet or regtest"
+
+	// NotInBestChain occurs when a block is known but not on the best chain
+	NotInBestChain sdk.CodeType = 113
+	// NotInBestChainMessage is the corresponding message
+	NotInBestChainMessage = "Block with digest %x is not on the best chain"
+
+	// NoLocatorMatch occurs when no digest of a block locator is on the best chain
+	NoLocatorMatch sdk.CodeType = 114
+	// NoLocatorMatchMessage is the corresponding message
+	NoLocatorMatchMessage = "None of the %d locator digests is on the best chain"
+
 	// 200-block -- AddHeaders
 
 	// UnexpectedRetarget indicates a retarget was seen during AddHeaders loop
@@ -68,6 +88,21 @@ const (
 	// UnexpectedRetargetMessage is the corresponding message
 	UnexpectedRetargetMessage = "Target changed unexpectedly at block %x"
 
+	// BadMinDifficulty indicates a block that should use the minimum difficulty did not
+	BadMinDifficulty sdk.CodeType = 202
+	// BadMinDifficultyMessage is the corresponding message
+	BadMinDifficultyMessage = "Block %x is more than twice the target spacing after its parent and must use the minimum difficulty"
+
+	// TimestampTooEarly indicates a block timestamp at or below the median of the previous 11 blocks
+	TimestampTooEarly sdk.CodeType = 203
+	// TimestampTooEarlyMessage is the corresponding message
+	TimestampTooEarlyMessage = "Block %x has timestamp %d, which is not after the median time past %d"
+
+	// TimestampTooFarInFuture indicates a block timestamp too far ahead of the current block time
+	TimestampTooFarInFuture sdk.CodeType = 204
+	// TimestampTooFarInFutureMessage is the corresponding message
+	TimestampTooFarInFutureMessage = "Block %x has timestamp %d, which is more than %d seconds after the current time %d"
+
 	// 300-block AddHeadersWithRetarget
 
 	// WrongEnd means the end block is at the wrong height
@@ -90,12 +125,17 @@ const (
 	// BadRetargetMessage is the corresponding message
 	BadRetargetMessage = "Invalid retarget provided"
 
+	// Timewarp means the first block of a period is timestamped too far before its parent (BIP94)
+	Timewarp sdk.CodeType = 305
+	// TimewarpMessage is the corresponding message
+	TimewarpMessage = "Block %x has timestamp %d, which is more than %d seconds before its parent's timestamp %d"
+
 	// 400-block -- MarkNewBestHeight
 
-	// LimitTooHigh indicates that the requested limit is >2016
+	// LimitTooHigh indicates that the requested limit is longer than a retarget period
 	LimitTooHigh sdk.CodeType = 402
 	// LimitTooHighMessage is the corresponding message
-	LimitTooHighMessage = "Requested lookup limit must be 2016 or lower. Got %d"
+	LimitTooHighMessage = "Requested lookup limit must be %d or lower. Got %d"
 
 	// NotBestKnown means a block should have been the best known, but wasn't
 	NotBestKnown sdk.CodeType = 403
@@ -126,15 +166,15 @@ const (
 	// UnknownRequestMessage is the corresponding message
 	UnknownRequestMessage = "Request not found"
 
-	// SpendsLength means the spend value is not 36 bytes
+	// SpendsLength means the spend value is not the allowed length
 	SpendsLength sdk.CodeType = 602
 	// SpendsLengthMessage is the corresponding message
-	SpendsLengthMessage = "Spends value is not 36 bytes"
+	SpendsLengthMessage = "Spends value is not %d bytes"
 
 	// PaysLength means the pays value is greater than 50 bytes
 	PaysLength sdk.CodeType = 603
 	// PaysLengthMessage is the corresponding message
-	PaysLengthMessage = "Pays value is greater than 50 bytes"
+	PaysLengthMessage = "Pays value is greater than %d bytes"
 
 	// InvalidVin means the vin is not valid
 	InvalidVin sdk.CodeType = 604
@@ -179,12 +219,92 @@ const (
 	// ActionLength means the pays value is greater than 50 bytes
 	ActionLength sdk.CodeType = 612
 	// ActionLengthMessage is the corresponding message
-	ActionLengthMessage = "Action value is greater than 500 bytes"
+	ActionLengthMessage = "Action value is greater than %d bytes"
+
+	// NotRequestOwner means the signer did not create the request
+	NotRequestOwner sdk.CodeType = 613
+	// NotRequestOwnerMessage is the corresponding message
+	NotRequestOwnerMessage = "%s is not the creator of requestID %d"
+
+	// AlreadyProven means the transaction has already filled the request
+	AlreadyProven sdk.CodeType = 614
+	// AlreadyProvenMessage is the corresponding message
+	AlreadyProvenMessage = "Transaction %x has already filled requestID %d"
+
+	// UnknownProvenTx means no accepted proof includes the transaction
+	UnknownProvenTx sdk.CodeType = 615
+	// UnknownProvenTxMessage is the corresponding message
+	UnknownProvenTxMessage = "Transaction %x has not filled any requests"
+
+	// ExpiryPassed means a new request would already be expired
+	ExpiryPassed sdk.CodeType = 616
+	// ExpiryPassedMessage is the corresponding message
+	ExpiryPassedMessage = "%s expiry height %d is below the current height %d"
+
+	// ProofPastExpiry means the proof was confirmed after the request's
+	// Bitcoin expiry height
+	ProofPastExpiry sdk.CodeType = 617
+	// ProofPastExpiryMessage is the corresponding message
+	ProofPastExpiryMessage = "Proof confirmed at height %d is past the Bitcoin expiry height %d of requestID %d"
+
+	// NoFilledRequests means a proof was provided without any requests to fill
+	NoFilledRequests sdk.CodeType = 618
+	// NoFilledRequestsMessage is the corresponding message
+	NoFilledRequestsMessage = "Proof must fill at least one request"
+
+	// BountyTooLarge means the bounty overflows when escrowed for each fill
+	BountyTooLarge sdk.CodeType = 619
+	// BountyTooLargeMessage is the corresponding message
+	BountyTooLargeMessage = "Bounty %s is too large to escrow for %d fills"
+
+	// InsufficientEscrow means the request's escrow can not pay its bounty
+	InsufficientEscrow sdk.CodeType = 620
+	// InsufficientEscrowMessage is the corresponding message
+	InsufficientEscrowMessage = "Escrow %s can not pay the bounty %s of requestID %d"
 
 	// 700-block External
 
 	// ExternalError is an error from a dependency
 	ExternalError sdk.CodeType = 701
+
+	// 800-block Checkpoints
+
+	// CheckpointConflict means a block conflicts with a checkpoint at its height
+	CheckpointConflict sdk.CodeType = 801
+	// CheckpointConflictMessage is the corresponding message
+	CheckpointConflictMessage = "Block %x at height %d conflicts with checkpoint %x"
+
+	// UnknownCheckpoint means no checkpoint is set at a height
+	UnknownCheckpoint sdk.CodeType = 802
+	// UnknownCheckpointMessage is the corresponding message
+	UnknownCheckpointMessage = "No checkpoint at height %d"
+
+	// EmptyCheckpointProposal means a checkpoint proposal makes no changes
+	EmptyCheckpointProposal sdk.CodeType = 803
+	// EmptyCheckpointProposalMessage is the corresponding message
+	EmptyCheckpointProposalMessage = "Checkpoint proposal must add or remove a checkpoint, or raise the relay genesis"
+
+	// DuplicateCheckpoint means two checkpoints were given for the same height
+	DuplicateCheckpoint sdk.CodeType = 804
+	// DuplicateCheckpointMessage is the corresponding message
+	DuplicateCheckpointMessage = "More than one checkpoint at height %d"
+
+	// BadGenesisRaise means the relay genesis cannot be raised to a checkpoint
+	BadGenesisRaise sdk.CodeType = 805
+	// BadGenesisRaiseMessage is the corresponding message
+	BadGenesisRaiseMessage = "Cannot raise relay genesis to checkpoint at height %d. It must be on the best chain and above the current relay genesis"
+
+	// 900-block Orphans
+
+	// OrphanLimit means a signer has too many orphan chains pooled
+	OrphanLimit sdk.CodeType = 901
+	// OrphanLimitMessage is the corresponding message
+	OrphanLimitMessage = "Signer %s already has %d orphan chains pooled"
+
+	// OrphanBelowGenesis means an orphan chain could never connect to the relay
+	OrphanBelowGenesis sdk.CodeType = 902
+	// OrphanBelowGenesisMessage is the corresponding message
+	OrphanBelowGenesisMessage = "Orphan chain starting at height %d does not start above the relay genesis at height %d"
 )
 
 // ErrBadHeaderLength throws an error
@@ -207,6 +327,21 @@ func ErrUnknownBlock(codespace sdk.CodespaceType, label string, digest Hash256Di
 	return sdk.NewError(codespace, UnknownBlock, fmt.Sprintf(UnknownBlockMessage, label, digest))
 }
 
+// ErrBadMinDifficulty throws an error
+func ErrBadMinDifficulty(codespace sdk.CodespaceType, rawHeader RawHeader) sdk.Error {
+	return sdk.NewError(codespace, BadMinDifficulty, fmt.Sprintf(BadMinDifficultyMessage, rawHeader))
+}
+
+// ErrTimestampTooEarly throws an error
+func ErrTimestampTooEarly(codespace sdk.CodespaceType, rawHeader RawHeader, timestamp, medianTimePast uint) sdk.Error {
+	return sdk.NewError(codespace, TimestampTooEarly, fmt.Sprintf(TimestampTooEarlyMessage, rawHeader, timestamp, medianTimePast))
+}
+
+// ErrTimestampTooFarInFuture throws an error
+func ErrTimestampTooFarInFuture(codespace sdk.CodespaceType, rawHeader RawHeader, timestamp uint, maxDrift, now int64) sdk.Error {
+	return sdk.NewError(codespace, TimestampTooFarInFuture, fmt.Sprintf(TimestampTooFarInFutureMessage, rawHeader, timestamp, maxDrift, now))
+}
+
 // ErrUnexpectedRetarget throws an error
 func ErrUnexpectedRetarget(codespace sdk.CodespaceType, rawHeader RawHeader) sdk.Error {
 	return sdk.NewError(codespace, UnexpectedRetarget, fmt.Sprintf(UnexpectedRetargetMessage, rawHeader))
@@ -232,9 +367,14 @@ func ErrBadRetarget(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, BadRetarget, BadRetargetMessage)
 }
 
+// ErrTimewarp throws an error
+func ErrTimewarp(codespace sdk.CodespaceType, rawHeader RawHeader, timestamp uint, maxTimewarp int64, parentTimestamp uint) sdk.Error {
+	return sdk.NewError(codespace, Timewarp, fmt.Sprintf(TimewarpMessage, rawHeader, timestamp, maxTimewarp, parentTimestamp))
+}
+
 // ErrLimitTooHigh throws an error
-func ErrLimitTooHigh(codespace sdk.CodespaceType, limit uint32) sdk.Error {
-	return sdk.NewError(codespace, LimitTooHigh, fmt.Sprintf(LimitTooHighMessage, limit))
+func ErrLimitTooHigh(codespace sdk.CodespaceType, limit, max uint32) sdk.Error {
+	return sdk.NewError(codespace, LimitTooHigh, fmt.Sprintf(LimitTooHighMessage, max, limit))
 }
 
 // ErrNotBestKnown throws an error
@@ -277,6 +417,26 @@ func ErrBadOffset(codespace sdk.CodespaceType, digest Hash256Digest) sdk.Error {
 	return sdk.NewError(codespace, BadHexLen, fmt.Sprintf(BadOffsetMessage, digest))
 }
 
+// ErrUnknownHeight throws an error
+func ErrUnknownHeight(codespace sdk.CodespaceType, height uint32) sdk.Error {
+	return sdk.NewError(codespace, UnknownHeight, fmt.Sprintf(UnknownHeightMessage, height))
+}
+
+// ErrUnknownNetwork throws an error
+func ErrUnknownNetwork(codespace sdk.CodespaceType, network Network) sdk.Error {
+	return sdk.NewError(codespace, UnknownNetwork, fmt.Sprintf(UnknownNetworkMessage, network))
+}
+
+// ErrNotInBestChain throws an error
+func ErrNotInBestChain(codespace sdk.CodespaceType, digest Hash256Digest) sdk.Error {
+	return sdk.NewError(codespace, NotInBestChain, fmt.Sprintf(NotInBestChainMessage, digest))
+}
+
+// ErrNoLocatorMatch throws an error
+func ErrNoLocatorMatch(codespace sdk.CodespaceType, length int) sdk.Error {
+	return sdk.NewError(codespace, NoLocatorMatch, fmt.Sprintf(NoLocatorMatchMessage, length))
+}
+
 // ErrMarshalJSON throws an error
 func ErrMarshalJSON(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, MarshalJSON, MarshalJSONMessage)
@@ -293,18 +453,18 @@ func ErrUnknownRequest(codespace sdk.CodespaceType) sdk.Error {
 }
 
 // ErrSpendsLength throws an error
-func ErrSpendsLength(codespace sdk.CodespaceType) sdk.Error {
-	return sdk.NewError(codespace, SpendsLength, SpendsLengthMessage)
+func ErrSpendsLength(codespace sdk.CodespaceType, length uint32) sdk.Error {
+	return sdk.NewError(codespace, SpendsLength, fmt.Sprintf(SpendsLengthMessage, length))
 }
 
 // ErrPaysLength throws an error
-func ErrPaysLength(codespace sdk.CodespaceType) sdk.Error {
-	return sdk.NewError(codespace, PaysLength, PaysLengthMessage)
+func ErrPaysLength(codespace sdk.CodespaceType, maxLength uint32) sdk.Error {
+	return sdk.NewError(codespace, PaysLength, fmt.Sprintf(PaysLengthMessage, maxLength))
 }
 
 // ErrActionLength throws an error
-func ErrActionLength(codespace sdk.CodespaceType) sdk.Error {
-	return sdk.NewError(codespace, ActionLength, ActionLengthMessage)
+func ErrActionLength(codespace sdk.CodespaceType, maxLength uint32) sdk.Error {
+	return sdk.NewError(codespace, ActionLength, fmt.Sprintf(ActionLengthMessage, maxLength))
 }
 
 // ErrInvalidVin throws an error
@@ -347,7 +507,82 @@ func ErrNotEnoughConfs(codespace sdk.CodespaceType, requestID RequestID) sdk.Err
 	return sdk.NewError(codespace, NotEnoughConfs, fmt.Sprintf(NotEnoughConfsMessage, requestID))
 }
 
+// ErrNotRequestOwner throws an error
+func ErrNotRequestOwner(codespace sdk.CodespaceType, signer sdk.AccAddress, requestID RequestID) sdk.Error {
+	return sdk.NewError(codespace, NotRequestOwner, fmt.Sprintf(NotRequestOwnerMessage, signer, requestID))
+}
+
+// ErrAlreadyProven throws an error
+func ErrAlreadyProven(codespace sdk.CodespaceType, txid Hash256Digest, requestID RequestID) sdk.Error {
+	return sdk.NewError(codespace, AlreadyProven, fmt.Sprintf(AlreadyProvenMessage, txid, requestID))
+}
+
+// ErrUnknownProvenTx throws an error
+func ErrUnknownProvenTx(codespace sdk.CodespaceType, txid Hash256Digest) sdk.Error {
+	return sdk.NewError(codespace, UnknownProvenTx, fmt.Sprintf(UnknownProvenTxMessage, txid))
+}
+
+// ErrExpiryPassed throws an error
+func ErrExpiryPassed(codespace sdk.CodespaceType, chain string, expiry, current int64) sdk.Error {
+	return sdk.NewError(codespace, ExpiryPassed, fmt.Sprintf(ExpiryPassedMessage, chain, expiry, current))
+}
+
+// ErrProofPastExpiry throws an error
+func ErrProofPastExpiry(codespace sdk.CodespaceType, height, expiry uint32, requestID RequestID) sdk.Error {
+	return sdk.NewError(codespace, ProofPastExpiry, fmt.Sprintf(ProofPastExpiryMessage, height, expiry, requestID))
+}
+
+// ErrNoFilledRequests throws an error
+func ErrNoFilledRequests(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, NoFilledRequests, NoFilledRequestsMessage)
+}
+
+// ErrBountyTooLarge throws an error
+func ErrBountyTooLarge(codespace sdk.CodespaceType, bounty string, fills uint32) sdk.Error {
+	return sdk.NewError(codespace, BountyTooLarge, fmt.Sprintf(BountyTooLargeMessage, bounty, fills))
+}
+
+// ErrInsufficientEscrow throws an error
+func ErrInsufficientEscrow(codespace sdk.CodespaceType, escrow, bounty string, requestID RequestID) sdk.Error {
+	return sdk.NewError(codespace, InsufficientEscrow, fmt.Sprintf(InsufficientEscrowMessage, escrow, bounty, requestID))
+}
+
 // ErrExternal converts any external error into an sdk error
 func ErrExternal(codespace sdk.CodespaceType, err error) sdk.Error {
 	return sdk.NewError(codespace, ExternalError, err.Error())
 }
+
+// ErrCheckpointConflict throws an error
+func ErrCheckpointConflict(codespace sdk.CodespaceType, digest Hash256Digest, height uint32, checkpoint Hash256Digest) sdk.Error {
+	return sdk.NewError(codespace, CheckpointConflict, fmt.Sprintf(CheckpointConflictMessage, digest, height, checkpoint))
+}
+
+// ErrUnknownCheckpoint throws an error
+func ErrUnknownCheckpoint(codespace sdk.CodespaceType, height uint32) sdk.Error {
+	return sdk.NewError(codespace, UnknownCheckpoint, fmt.Sprintf(UnknownCheckpointMessage, height))
+}
+
+// ErrEmptyCheckpointProposal throws an error
+func ErrEmptyCheckpointProposal(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, EmptyCheckpointProposal, EmptyCheckpointProposalMessage)
+}
+
+// ErrDuplicateCheckpoint throws an error
+func ErrDuplicateCheckpoint(codespace sdk.CodespaceType, height uint32) sdk.Error {
+	return sdk.NewError(codespace, DuplicateCheckpoint, fmt.Sprintf(DuplicateCheckpointMessage, height))
+}
+
+// ErrBadGenesisRaise throws an error
+func ErrBadGenesisRaise(codespace sdk.CodespaceType, height uint32) sdk.Error {
+	return sdk.NewError(codespace, BadGenesisRaise, fmt.Sprintf(BadGenesisRaiseMessage, height))
+}
+
+// ErrOrphanLimit throws an error
+func ErrOrphanLimit(codespace sdk.CodespaceType, signer sdk.AccAddress, limit uint32) sdk.Error {
+	return sdk.NewError(codespace, OrphanLimit, fmt.Sprintf(OrphanLimitMessage, signer, limit))
+}
+
+// ErrOrphanBelowGenesis throws an error
+func ErrOrphanBelowGenesis(codespace sdk.CodespaceType, height, genesisHeight uint32) sdk.Error {
+	return sdk.NewError(codespace, OrphanBelowGenesis, fmt.Sprintf(OrphanBelowGenesisMessage, height, genesisHeight))
+}
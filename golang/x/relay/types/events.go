@@ -10,13 +10,19 @@ import (
 
 // Relay module event types
 const (
-	EventTypeExtension     = "extension"
-	EventTypeReorg         = "reorg"
-	EventTypeProofRequest  = "proof_request"
-	EventTypeProofProvided = "proof_provided"
+	EventTypeExtension        = "extension"
+	EventTypeReorg            = "reorg"
+	EventTypeProofRequest     = "proof_request"
+	EventTypeProofProvided    = "proof_provided"
+	EventTypeRequestReopened  = "request_reopened"
+	EventTypeOrphanChain      = "orphan_chain"
+	EventTypeRequestCancelled = "request_cancelled"
+	EventTypeRequestExpired   = "request_expired"
+	EventTypeParamsClamped    = "params_clamped"
 
 	AttributeKeyFirstBlock = "first_block"
 	AttributeKeyLastBlock  = "last_block"
+	AttributeKeyAnchor     = "anchor"
 
 	AttributeKeyPreviousBest = "previous_best"
 	AttributeKeyNewBest      = "new_best"
@@ -27,9 +33,14 @@ const (
 	AttributeKeySpends    = "spends"
 	AttributeKeyPaysValue = "value"
 	AttributeKeyOrigin    = "origin"
+	AttributeKeyCreator   = "creator"
 
 	AttributeKeyTXID   = "txid"
 	AttributeKeyFilled = "filled"
+
+	AttributeKeyConfirmingBlock = "confirming_block"
+
+	AttributeKeyParams = "params"
 )
 
 // NewReorgEvent instantiates a reorg event
@@ -51,6 +62,16 @@ func NewExtensionEvent(first, last BitcoinHeader) sdk.Event {
 	)
 }
 
+// NewOrphanChainEvent instantiates an orphan chain event
+func NewOrphanChainEvent(anchor Hash256Digest, first, last BitcoinHeader) sdk.Event {
+	return sdk.NewEvent(
+		EventTypeOrphanChain,
+		sdk.NewAttribute(AttributeKeyAnchor, "0x"+hex.EncodeToString(anchor[:])),
+		sdk.NewAttribute(AttributeKeyFirstBlock, "0x"+hex.EncodeToString(first.Hash[:])),
+		sdk.NewAttribute(AttributeKeyLastBlock, "0x"+hex.EncodeToString(last.Hash[:])),
+	)
+}
+
 // NewProofRequestEvent instantiates a proof request event
 func NewProofRequestEvent(pays, spends []byte, paysValue uint64, id RequestID, origin Origin) sdk.Event {
 	return sdk.NewEvent(
@@ -72,3 +93,39 @@ func NewProofProvidedEvent(txid Hash256Digest, filled []RequestID) sdk.Event {
 		sdk.NewAttribute(AttributeKeyFilled, string(filledJSON)),
 	)
 }
+
+// NewRequestReopenedEvent instantiates a request reopened event
+func NewRequestReopenedEvent(fill Fill) sdk.Event {
+	return sdk.NewEvent(
+		EventTypeRequestReopened,
+		sdk.NewAttribute(AttributeKeyRequestID, fmt.Sprintf("%d", fill.ID)),
+		sdk.NewAttribute(AttributeKeyTXID, "0x"+hex.EncodeToString(fill.TxID[:])),
+		sdk.NewAttribute(AttributeKeyConfirmingBlock, "0x"+hex.EncodeToString(fill.ConfirmingDigest[:])),
+	)
+}
+
+// NewRequestCancelledEvent instantiates a request cancelled event
+func NewRequestCancelledEvent(id RequestID, creator sdk.AccAddress) sdk.Event {
+	return sdk.NewEvent(
+		EventTypeRequestCancelled,
+		sdk.NewAttribute(AttributeKeyRequestID, fmt.Sprintf("%d", id)),
+		sdk.NewAttribute(AttributeKeyCreator, creator.String()),
+	)
+}
+
+// NewRequestExpiredEvent instantiates a request expired event
+func NewRequestExpiredEvent(id RequestID) sdk.Event {
+	return sdk.NewEvent(
+		EventTypeRequestExpired,
+		sdk.NewAttribute(AttributeKeyRequestID, fmt.Sprintf("%d", id)),
+	)
+}
+
+// NewParamsClampedEvent instantiates a params clamped event
+func NewParamsClampedEvent(params Params) sdk.Event {
+	paramsJSON, _ := json.Marshal(params)
+	return sdk.NewEvent(
+		EventTypeParamsClamped,
+		sdk.NewAttribute(AttributeKeyParams, string(paramsJSON)),
+	)
+}
@@ -0,0 +1,105 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Link is a stored child -> parent relationship between two headers
+type Link struct {
+	Digest Hash256Digest `json:"digest"`
+	Parent Hash256Digest `json:"parent"`
+}
+
+// HeightDigest is an entry in the best chain height index
+type HeightDigest struct {
+	Height uint32        `json:"height"`
+	Digest Hash256Digest `json:"digest"`
+}
+
+// Checkpoint pins the best chain block at a height to a known digest
+type Checkpoint struct {
+	Height uint32        `json:"height"`
+	Digest Hash256Digest `json:"digest"`
+}
+
+// String formats a Checkpoint
+func (c Checkpoint) String() string {
+	return fmt.Sprintf("%d: %x", c.Height, c.Digest)
+}
+
+// HeaderWork is the accumulated work of the chain ending in a header
+type HeaderWork struct {
+	Digest    Hash256Digest `json:"digest"`
+	ChainWork sdk.Uint      `json:"chainWork"`
+}
+
+// IdentifiedRequest is a ProofRequest along with the ID it is stored under
+type IdentifiedRequest struct {
+	ID      RequestID    `json:"id"`
+	Request ProofRequest `json:"request"`
+}
+
+// ChainState is a complete snapshot of the relay's store. It is produced when
+// exporting genesis, and can be imported to rebuild an identical store
+type ChainState struct {
+	RelayGenesis           Hash256Digest       `json:"relayGenesis"`
+	BestKnownDigest        Hash256Digest       `json:"bestKnownDigest"`
+	LastReorgLCA           Hash256Digest       `json:"lastReorgLCA"`
+	CurrentEpochDifficulty sdk.Uint            `json:"currentEpochDifficulty"`
+	PrevEpochDifficulty    sdk.Uint            `json:"prevEpochDifficulty"`
+	Headers                []BitcoinHeader     `json:"headers"`
+	Links                  []Link              `json:"links"`
+	Heights                []HeightDigest      `json:"heights"`
+	ChainWork              []HeaderWork        `json:"chainWork"`
+	Requests               []IdentifiedRequest `json:"requests"`
+	Fills                  []Fill              `json:"fills"`
+	RequestFills           []Fill              `json:"requestFills"`
+	Orphans                []OrphanChain       `json:"orphans"`
+	MMRBaseHeight          uint32              `json:"mmrBaseHeight"`
+	MMRNodes               []MMRNode           `json:"mmrNodes"`
+	NextRequestID          RequestID           `json:"nextRequestID"`
+}
+
+// Validate checks that every digest in the snapshot refers to an exported
+// header
+func (s ChainState) Validate() error {
+	known := make(map[Hash256Digest]bool)
+	for _, header := range s.Headers {
+		_, err := header.Validate()
+		if err != nil {
+			return err
+		}
+		known[header.Hash] = true
+	}
+
+	if !known[s.RelayGenesis] {
+		return errors.New("relay genesis is not in the exported headers")
+	}
+	if !known[s.BestKnownDigest] {
+		return errors.New("best known digest is not in the exported headers")
+	}
+	if !known[s.LastReorgLCA] {
+		return errors.New("last reorg LCA is not in the exported headers")
+	}
+
+	for _, link := range s.Links {
+		if !known[link.Digest] || !known[link.Parent] {
+			return fmt.Errorf("link from %x to %x references an unknown header", link.Digest, link.Parent)
+		}
+	}
+	for _, entry := range s.Heights {
+		if !known[entry.Digest] {
+			return fmt.Errorf("height %d references unknown header %x", entry.Height, entry.Digest)
+		}
+	}
+	for _, entry := range s.ChainWork {
+		if !known[entry.Digest] {
+			return fmt.Errorf("chain work references unknown header %x", entry.Digest)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// RelayHooks is an interface through which the keeper notifies other modules
+// of changes to its view of the Bitcoin chain
+type RelayHooks interface {
+	// AfterChainExtended is called after a chain of headers is ingested. The
+	// headers need not be on the best chain
+	AfterChainExtended(ctx sdk.Context, headers []BitcoinHeader)
+	// AfterReorg is called after the best known digest moves from prevBest
+	// to newBest. Orphaned lists the blocks that left the best chain, from
+	// prevBest down to the block above lca. It is empty when newBest
+	// descends from prevBest
+	AfterReorg(ctx sdk.Context, prevBest, newBest, lca Hash256Digest, orphaned []Hash256Digest)
+}
+
+var _ RelayHooks = MultiRelayHooks{}
+
+// MultiRelayHooks combines multiple relay hooks. They are called in order
+type MultiRelayHooks []RelayHooks
+
+// NewMultiRelayHooks instantiates a MultiRelayHooks
+func NewMultiRelayHooks(hooks ...RelayHooks) MultiRelayHooks {
+	return hooks
+}
+
+// AfterChainExtended calls AfterChainExtended on each hook
+func (h MultiRelayHooks) AfterChainExtended(ctx sdk.Context, headers []BitcoinHeader) {
+	for i := range h {
+		h[i].AfterChainExtended(ctx, headers)
+	}
+}
+
+// AfterReorg calls AfterReorg on each hook
+func (h MultiRelayHooks) AfterReorg(ctx sdk.Context, prevBest, newBest, lca Hash256Digest, orphaned []Hash256Digest) {
+	for i := range h {
+		h[i].AfterReorg(ctx, prevBest, newBest, lca, orphaned)
+	}
+}
@@ -16,6 +16,47 @@ const (
 	// RequestStorePrefix to be used when making requests
 	RequestStorePrefix = ModuleName + "-requests-"
 
+	// HeightStorePrefix to be used when accessing the best chain height index
+	HeightStorePrefix = ModuleName + "-heights-"
+
+	// ChainWorkStorePrefix to be used when accessing accumulated header work
+	ChainWorkStorePrefix = ModuleName + "-work-"
+
+	// TipStorePrefix to be used when accessing the chain tips
+	TipStorePrefix = ModuleName + "-tips-"
+
+	// FillStorePrefix to be used when accessing the blocks that filled requests
+	FillStorePrefix = ModuleName + "-fills-"
+
+	// RequestFillStorePrefix to be used when accessing the fills of each request
+	RequestFillStorePrefix = ModuleName + "-request-fills-"
+
+	// ProvenTxStorePrefix to be used when accessing the requests filled by
+	// each transaction
+	ProvenTxStorePrefix = ModuleName + "-proven-txs-"
+
+	// RequestExpiryStorePrefix to be used when accessing the expiry index of
+	// active requests by Cosmos block height
+	RequestExpiryStorePrefix = ModuleName + "-request-expiry-"
+
+	// RequestBTCExpiryStorePrefix to be used when accessing the expiry index
+	// of active requests by Bitcoin height
+	RequestBTCExpiryStorePrefix = ModuleName + "-request-btc-expiry-"
+
+	// OrphanStorePrefix to be used when accessing the orphan pool
+	OrphanStorePrefix = ModuleName + "-orphans-"
+
+	// OrphanExpiryStorePrefix to be used when accessing the orphan pool's
+	// expiry index
+	OrphanExpiryStorePrefix = ModuleName + "-orphan-expiry-"
+
+	// MMRStorePrefix to be used when accessing the best chain's Merkle
+	// Mountain Range
+	MMRStorePrefix = ModuleName + "-mmr-"
+
+	// CheckpointStorePrefix to be used when accessing checkpoints
+	CheckpointStorePrefix = ModuleName + "-checkpoints-"
+
 	// ChainStorePrefix to be used when accessing chain metadata
 	ChainStorePrefix = ModuleName + "-chain-"
 
@@ -28,6 +69,16 @@ const (
 	// LastReorgLCAStorage is the storage key for the last reorg LCA
 	LastReorgLCAStorage = "LastReorgLCA"
 
+	// StoreVersionStorage is the storage key for the layout version of the store
+	StoreVersionStorage = "StoreVersion"
+
+	// MMRBaseHeightStorage is the storage key for the height of the first
+	// leaf of the Merkle Mountain Range
+	MMRBaseHeightStorage = "MMRBaseHeight"
+
+	// ChainParamsStorage is the storage key for the Bitcoin chain parameters
+	ChainParamsStorage = "ChainParams"
+
 	// CurrentEpochDiffStorage is the storage key for the current epoch difficulty
 	CurrentEpochDiffStorage = "currentEpochDifficulty"
 
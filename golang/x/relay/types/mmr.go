@@ -0,0 +1,130 @@
+package types
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/summa-tx/bitcoin-spv/golang/btcspv"
+)
+
+// MMRNode is a node of the relay's Merkle Mountain Range. The node at Level
+// l and Index i commits to the leaves from i*2^l up to (i+1)*2^l. Leaves are
+// best chain digests at Level 0, in height order from the MMR base height
+type MMRNode struct {
+	Level  uint8         `json:"level"`
+	Index  uint32        `json:"index"`
+	Digest Hash256Digest `json:"digest"`
+}
+
+// MMRProof proves that a digest is the leaf at LeafIndex of a Merkle
+// Mountain Range with LeafCount leaves. Siblings run from the leaf up to its
+// peak, and Peaks lists every peak from left to right
+type MMRProof struct {
+	Height    uint32          `json:"height"`
+	LeafIndex uint32          `json:"leafIndex"`
+	LeafCount uint32          `json:"leafCount"`
+	Leaf      Hash256Digest   `json:"leaf"`
+	Siblings  []Hash256Digest `json:"siblings"`
+	Peaks     []Hash256Digest `json:"peaks"`
+}
+
+// MMRParent hashes two nodes into their parent
+func MMRParent(left, right Hash256Digest) Hash256Digest {
+	return btcspv.Hash256(append(left[:], right[:]...))
+}
+
+// MMRPeakNodes returns the level and index of each peak of a Merkle Mountain
+// Range with count leaves, from left to right. There is one peak per set bit
+// of count. The digests are not set
+func MMRPeakNodes(count uint32) []MMRNode {
+	peaks := []MMRNode{}
+	offset := uint32(0)
+	for level := 31; level >= 0; level-- {
+		size := uint32(1) << uint(level)
+		if count&size == 0 {
+			continue
+		}
+		peaks = append(peaks, MMRNode{Level: uint8(level), Index: offset >> uint(level)})
+		offset += size
+	}
+	return peaks
+}
+
+// BagMMRPeaks folds the peaks of a Merkle Mountain Range with count leaves
+// starting at baseHeight into its root, from right to left, and commits to
+// baseHeight and count. Without them a root could be opened as a range with
+// fewer leaves, or with its leaves at other heights. An empty range has the
+// zero digest as its root
+func BagMMRPeaks(baseHeight, count uint32, peaks []Hash256Digest) Hash256Digest {
+	if len(peaks) == 0 {
+		return Hash256Digest{}
+	}
+	bagged := peaks[len(peaks)-1]
+	for i := len(peaks) - 2; i >= 0; i-- {
+		bagged = MMRParent(peaks[i], bagged)
+	}
+	var prefix [8]byte
+	binary.LittleEndian.PutUint32(prefix[:4], baseHeight)
+	binary.LittleEndian.PutUint32(prefix[4:], count)
+	return btcspv.Hash256(append(prefix[:], bagged[:]...))
+}
+
+// Verify checks the proof against a trusted root of a Merkle Mountain Range.
+// The proof's leaf count and height must match the root's
+func (p MMRProof) Verify(root MMRRoot) bool {
+	if p.LeafCount != root.LeafCount || p.LeafIndex >= root.LeafCount {
+		return false
+	}
+	if uint64(p.Height) != uint64(root.BaseHeight)+uint64(p.LeafIndex) {
+		return false
+	}
+	peaks := MMRPeakNodes(root.LeafCount)
+	if len(peaks) != len(p.Peaks) {
+		return false
+	}
+
+	for i, peak := range peaks {
+		first := peak.Index << peak.Level
+		if p.LeafIndex < first || p.LeafIndex-first >= uint32(1)<<peak.Level {
+			continue
+		}
+		if len(p.Siblings) != int(peak.Level) {
+			return false
+		}
+
+		current := p.Leaf
+		for level, sibling := range p.Siblings {
+			if (p.LeafIndex>>uint(level))&1 == 0 {
+				current = MMRParent(current, sibling)
+			} else {
+				current = MMRParent(sibling, current)
+			}
+		}
+		return current == p.Peaks[i] && BagMMRPeaks(root.BaseHeight, root.LeafCount, p.Peaks) == root.Root
+	}
+	return false
+}
+
+// String formats an MMRProof
+func (p MMRProof) String() string {
+	return fmt.Sprintf(
+		"Height: %d, Leaf: %d of %d, Digest LE: %s, Siblings: %d, Peaks: %d",
+		p.Height, p.LeafIndex, p.LeafCount, "0x"+hex.EncodeToString(p.Leaf[:]),
+		len(p.Siblings), len(p.Peaks))
+}
+
+// MMRRoot is the root of the relay's Merkle Mountain Range. Its leaves are
+// the best chain digests from BaseHeight up to the best known digest
+type MMRRoot struct {
+	Root       Hash256Digest `json:"root"`
+	LeafCount  uint32        `json:"leafCount"`
+	BaseHeight uint32        `json:"baseHeight"`
+}
+
+// String formats an MMRRoot
+func (r MMRRoot) String() string {
+	return fmt.Sprintf(
+		"Root: %s, Leaves: %d, Base Height: %d",
+		"0x"+hex.EncodeToString(r.Root[:]), r.LeafCount, r.BaseHeight)
+}
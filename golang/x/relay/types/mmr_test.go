@@ -0,0 +1,66 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMMRProofVerify(t *testing.T) {
+	a, b, c := Hash256Digest{1}, Hash256Digest{2}, Hash256Digest{3}
+	ab := MMRParent(a, b)
+	bagged := MMRParent(ab, c)
+	root := MMRRoot{Root: BagMMRPeaks(10, 3, []Hash256Digest{ab, c}), LeafCount: 3, BaseHeight: 10}
+
+	// the root commits to the base height and the leaf count
+	assert.NotEqual(t, bagged, root.Root)
+	assert.NotEqual(t, root.Root, BagMMRPeaks(10, 2, []Hash256Digest{ab, c}))
+	assert.NotEqual(t, root.Root, BagMMRPeaks(11, 3, []Hash256Digest{ab, c}))
+
+	proof := MMRProof{
+		Height:    12,
+		LeafIndex: 2,
+		LeafCount: 3,
+		Leaf:      c,
+		Siblings:  []Hash256Digest{},
+		Peaks:     []Hash256Digest{ab, c},
+	}
+	assert.True(t, proof.Verify(root))
+
+	// errors if the height does not match the leaf index
+	proof.Height = 11
+	assert.False(t, proof.Verify(root))
+
+	// errors if the root claims a different base height
+	proof.Height = 13
+	moved := root
+	moved.BaseHeight = 11
+	assert.False(t, proof.Verify(moved))
+
+	// errors if c is opened as the second leaf of a 2 leaf range
+	forged := MMRProof{
+		Height:    11,
+		LeafIndex: 1,
+		LeafCount: 2,
+		Leaf:      c,
+		Siblings:  []Hash256Digest{ab},
+		Peaks:     []Hash256Digest{bagged},
+	}
+	assert.False(t, forged.Verify(root))
+	root.LeafCount = 2
+	assert.False(t, forged.Verify(root))
+
+	// errors if the bagged peaks are opened as the leaf of a 1 leaf range
+	forged = MMRProof{
+		Height:    10,
+		LeafIndex: 0,
+		LeafCount: 1,
+		Leaf:      bagged,
+		Siblings:  []Hash256Digest{},
+		Peaks:     []Hash256Digest{bagged},
+	}
+	root.LeafCount = 3
+	assert.False(t, forged.Verify(root))
+	root.LeafCount = 1
+	assert.False(t, forged.Verify(root))
+}
@@ -107,11 +107,8 @@ type MsgMarkNewHeaviest struct {
 }
 
 // NewMsgMarkNewHeaviest instantiates a MsgMarkNewHeaviest
+// A limit of 0 uses the relay's default lookup limit
 func NewMsgMarkNewHeaviest(address sdk.AccAddress, ancestor Hash256Digest, currentBest RawHeader, newBest RawHeader, limit uint32) MsgMarkNewHeaviest {
-	if limit == 0 {
-		limit = DefaultLookupLimit
-	}
-
 	return MsgMarkNewHeaviest{
 		address,
 		ancestor,
@@ -161,10 +158,16 @@ type MsgNewRequest struct {
 	NumConfs  uint8          `json:"numConfs"`
 	Origin    Origin         `json:"origin"`
 	Action    HexBytes       `json:"action"`
+	MaxFills  uint32         `json:"maxFills"`
+	// Optional expiry heights on the Cosmos and Bitcoin chains. 0 is none
+	ExpiryHeight    int64  `json:"expiryHeight"`
+	BTCExpiryHeight uint32 `json:"btcExpiryHeight"`
+	// Optional bounty paid for each fill. The signer escrows it maxFills times
+	Bounty sdk.Coins `json:"bounty"`
 }
 
 // NewMsgNewRequest instantiates a MsgNewRequest
-func NewMsgNewRequest(address sdk.AccAddress, spends, pays []byte, paysValue uint64, numConfs uint8, origin Origin, action HexBytes) MsgNewRequest {
+func NewMsgNewRequest(address sdk.AccAddress, spends, pays []byte, paysValue uint64, numConfs uint8, origin Origin, action HexBytes, options RequestOptions) MsgNewRequest {
 	return MsgNewRequest{
 		address,
 		spends,
@@ -173,6 +176,20 @@ func NewMsgNewRequest(address sdk.AccAddress, spends, pays []byte, paysValue uin
 		numConfs,
 		origin,
 		action,
+		options.MaxFills,
+		options.ExpiryHeight,
+		options.BTCExpiryHeight,
+		options.Bounty,
+	}
+}
+
+// Options returns the request's optional settings
+func (msg MsgNewRequest) Options() RequestOptions {
+	return RequestOptions{
+		MaxFills:        msg.MaxFills,
+		ExpiryHeight:    msg.ExpiryHeight,
+		BTCExpiryHeight: msg.BTCExpiryHeight,
+		Bounty:          msg.Bounty,
 	}
 }
 
@@ -184,17 +201,17 @@ func (msg MsgNewRequest) GetSigners() []sdk.AccAddress {
 // Type returns an identifier
 func (msg MsgNewRequest) Type() string { return "new_request" }
 
-// ValidateBasic runs stateless validation
+// ValidateBasic runs stateless validation. The length limits are module
+// params, and are checked by the keeper
 func (msg MsgNewRequest) ValidateBasic() sdk.Error {
 	// TODO: validate output types
-	if len(msg.Spends) != 36 && len(msg.Spends) != 0 {
-		return ErrSpendsLength(DefaultCodespace)
-	}
-	if len(msg.Pays) > 50 {
-		return ErrPaysLength(DefaultCodespace)
+	if !msg.Bounty.IsValid() {
+		return sdk.ErrInvalidCoins(msg.Bounty.String())
 	}
-	if len(msg.Action) > 500 {
-		return ErrActionLength(DefaultCodespace)
+	// The bounty is escrowed once for each fill
+	fills := msg.Options().Fills()
+	if !escrowFits(msg.Bounty, fills) {
+		return ErrBountyTooLarge(DefaultCodespace, msg.Bounty.String(), fills)
 	}
 	return nil
 }
@@ -230,6 +247,11 @@ func (msg MsgProvideProof) GetSigners() []sdk.AccAddress {
 
 // ValidateBasic runs stateless validation
 func (msg MsgProvideProof) ValidateBasic() sdk.Error {
+	// A proof that fills nothing is never recorded, so it could be replayed
+	if len(msg.Filled.Filled) == 0 {
+		return ErrNoFilledRequests(DefaultCodespace)
+	}
+
 	valid, err := msg.Filled.Proof.Validate()
 	if !valid || err != nil {
 		return FromBTCSPVError(DefaultCodespace, err)
@@ -248,3 +270,43 @@ func (msg MsgProvideProof) GetSignBytes() []byte {
 
 // Route returns the route key
 func (msg MsgProvideProof) Route() string { return RouterKey }
+
+/***** CancelRequest *****/
+
+// MsgCancelRequest defines a CancelRequest message
+type MsgCancelRequest struct {
+	Signer sdk.AccAddress `json:"signer"`
+	ID     RequestID      `json:"id"`
+}
+
+// NewMsgCancelRequest instantiates a MsgCancelRequest
+func NewMsgCancelRequest(address sdk.AccAddress, id RequestID) MsgCancelRequest {
+	return MsgCancelRequest{
+		address,
+		id,
+	}
+}
+
+// GetSigners gets signers
+func (msg MsgCancelRequest) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Signer}
+}
+
+// ValidateBasic runs stateless validation. Ownership is checked by the keeper
+func (msg MsgCancelRequest) ValidateBasic() sdk.Error {
+	if msg.Signer.Empty() {
+		return sdk.ErrInvalidAddress(msg.Signer.String())
+	}
+	return nil
+}
+
+// Type returns an identifier
+func (msg MsgCancelRequest) Type() string { return "cancel_request" }
+
+// GetSignBytes returns the sighash for the message
+func (msg MsgCancelRequest) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// Route returns the route key
+func (msg MsgCancelRequest) Route() string { return RouterKey }
@@ -0,0 +1,48 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMsgProvideProofValidateBasic(t *testing.T) {
+	// errors if the proof fills no requests
+	msg := NewMsgProvideProof(nil, FilledRequests{})
+	assert.Equal(t, sdk.CodeType(NoFilledRequests), msg.ValidateBasic().Code())
+
+	// the proof itself is then validated
+	msg = NewMsgProvideProof(nil, FilledRequests{
+		Proof:  SPVProof{Vin: []byte{0}},
+		Filled: []FilledRequestInfo{{}},
+	})
+	assert.Equal(t, sdk.CodeType(BitcoinSPV), msg.ValidateBasic().Code())
+}
+
+func TestMsgNewRequestValidateBasic(t *testing.T) {
+	msg := NewMsgNewRequest(nil, nil, nil, 0, 0, Local, nil, RequestOptions{})
+	assert.Nil(t, msg.ValidateBasic())
+
+	// errors if the bounty is invalid
+	msg = NewMsgNewRequest(nil, nil, nil, 0, 0, Local, nil, RequestOptions{
+		Bounty: sdk.Coins{sdk.Coin{Denom: "stake", Amount: sdk.NewInt(-1)}},
+	})
+	assert.Equal(t, sdk.CodeInvalidCoins, msg.ValidateBasic().Code())
+
+	// a bounty near the largest sdk.Int can be escrowed once
+	max := sdk.NewIntFromBigInt(new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), maxIntBits), big.NewInt(1)))
+	options := RequestOptions{Bounty: sdk.NewCoins(sdk.NewCoin("stake", max))}
+	msg = NewMsgNewRequest(nil, nil, nil, 0, 0, Local, nil, options)
+	assert.Nil(t, msg.ValidateBasic())
+
+	// errors if escrowing it for each fill would overflow
+	options.MaxFills = 2
+	msg = NewMsgNewRequest(nil, nil, nil, 0, 0, Local, nil, options)
+	assert.Equal(t, sdk.CodeType(BountyTooLarge), msg.ValidateBasic().Code())
+
+	options.Bounty = sdk.NewCoins(sdk.NewCoin("stake", max.QuoRaw(2)))
+	msg = NewMsgNewRequest(nil, nil, nil, 0, 0, Local, nil, options)
+	assert.Nil(t, msg.ValidateBasic())
+}
@@ -0,0 +1,47 @@
+package types
+
+import (
+	"github.com/summa-tx/bitcoin-spv/golang/btcspv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// OrphanChain is a chain of headers whose anchor was unknown when it was
+// submitted. It is held in the orphan pool until the anchor is ingested or
+// the Cosmos block height passes Expiry. Only raw headers are kept, as the
+// rest of each header can be recomputed from them
+type OrphanChain struct {
+	Signer      sdk.AccAddress `json:"signer"`
+	Expiry      int64          `json:"expiry"`
+	FirstHeight uint32         `json:"firstHeight"`
+	Headers     []RawHeader    `json:"headers"`
+}
+
+// NewOrphanChain instantiates an OrphanChain from a non-empty chain of
+// headers
+func NewOrphanChain(signer sdk.AccAddress, expiry int64, headers []BitcoinHeader) OrphanChain {
+	raw := make([]RawHeader, len(headers))
+	for i, header := range headers {
+		raw[i] = header.Raw
+	}
+	return OrphanChain{
+		Signer:      signer,
+		Expiry:      expiry,
+		FirstHeight: headers[0].Height,
+		Headers:     raw,
+	}
+}
+
+// BitcoinHeaders parses the orphan chain's headers
+func (o OrphanChain) BitcoinHeaders() []BitcoinHeader {
+	headers := make([]BitcoinHeader, len(o.Headers))
+	for i, raw := range o.Headers {
+		headers[i] = btcspv.HeaderFromRaw(raw, o.FirstHeight+uint32(i))
+	}
+	return headers
+}
+
+// Anchor returns the digest of the block the orphan chain builds on
+func (o OrphanChain) Anchor() Hash256Digest {
+	return btcspv.ExtractPrevBlockHashLE(o.Headers[0])
+}
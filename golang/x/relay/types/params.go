@@ -0,0 +1,194 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// DefaultParamspace is the name of the relay's params subspace
+const DefaultParamspace = ModuleName
+
+// Default values of the relay's params
+const (
+	// DefaultProofAncestorLimit is how far below the last reorg LCA a proof's
+	// confirming header may be
+	DefaultProofAncestorLimit uint32 = 240
+	// DefaultMaxLookupLimit is the highest limit a MarkNewHeaviest may set
+	DefaultMaxLookupLimit uint32 = 2016
+	// DefaultLookupLimit is the limit used when a lookup does not set one
+	DefaultLookupLimit uint32 = 18
+	// DefaultSpendsLength is the required length of a request's spends
+	DefaultSpendsLength uint32 = 36
+	// DefaultMaxPaysLength is the longest output script a request may pay
+	DefaultMaxPaysLength uint32 = 50
+	// DefaultMaxActionLength is the longest action a request may carry
+	DefaultMaxActionLength uint32 = 500
+	// DefaultForkPruneDepth is how far a fork tip may fall behind the best
+	// tip before its branch is pruned
+	DefaultForkPruneDepth uint32 = 2016
+	// DefaultHistoryRetention is how many best chain blocks below the tip
+	// are kept. 0 keeps the whole chain
+	DefaultHistoryRetention uint32 = 0
+	// DefaultMaxOrphanChains is how many chains with an unknown anchor are
+	// held until their anchor arrives. 0 disables the orphan pool
+	DefaultMaxOrphanChains uint32 = 64
+	// DefaultMaxOrphansPerSigner is how many of those chains one signer may
+	// have pooled at a time
+	DefaultMaxOrphansPerSigner uint32 = 8
+	// DefaultOrphanExpiry is how many blocks an orphan chain is held
+	DefaultOrphanExpiry uint32 = 100
+)
+
+// Params store keys
+var (
+	KeyProofAncestorLimit  = []byte("ProofAncestorLimit")
+	KeyMaxLookupLimit      = []byte("MaxLookupLimit")
+	KeyDefaultLookupLimit  = []byte("DefaultLookupLimit")
+	KeySpendsLength        = []byte("SpendsLength")
+	KeyMaxPaysLength       = []byte("MaxPaysLength")
+	KeyMaxActionLength     = []byte("MaxActionLength")
+	KeyForkPruneDepth      = []byte("ForkPruneDepth")
+	KeyHistoryRetention    = []byte("HistoryRetention")
+	KeyMaxOrphanChains     = []byte("MaxOrphanChains")
+	KeyMaxOrphansPerSigner = []byte("MaxOrphansPerSigner")
+	KeyOrphanExpiry        = []byte("OrphanExpiry")
+)
+
+var _ params.ParamSet = &Params{}
+
+// Params are the governance-tunable limits of the relay. A ForkPruneDepth
+// or HistoryRetention of 0 disables that kind of pruning, and a
+// MaxOrphanChains of 0 disables the orphan pool
+type Params struct {
+	ProofAncestorLimit  uint32 `json:"proofAncestorLimit"`
+	MaxLookupLimit      uint32 `json:"maxLookupLimit"`
+	DefaultLookupLimit  uint32 `json:"defaultLookupLimit"`
+	SpendsLength        uint32 `json:"spendsLength"`
+	MaxPaysLength       uint32 `json:"maxPaysLength"`
+	MaxActionLength     uint32 `json:"maxActionLength"`
+	ForkPruneDepth      uint32 `json:"forkPruneDepth"`
+	HistoryRetention    uint32 `json:"historyRetention"`
+	MaxOrphanChains     uint32 `json:"maxOrphanChains"`
+	MaxOrphansPerSigner uint32 `json:"maxOrphansPerSigner"`
+	OrphanExpiry        uint32 `json:"orphanExpiry"`
+}
+
+// ParamKeyTable returns the key table for the relay's params
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// DefaultParams returns the default relay params
+func DefaultParams() Params {
+	return Params{
+		ProofAncestorLimit:  DefaultProofAncestorLimit,
+		MaxLookupLimit:      DefaultMaxLookupLimit,
+		DefaultLookupLimit:  DefaultLookupLimit,
+		SpendsLength:        DefaultSpendsLength,
+		MaxPaysLength:       DefaultMaxPaysLength,
+		MaxActionLength:     DefaultMaxActionLength,
+		ForkPruneDepth:      DefaultForkPruneDepth,
+		HistoryRetention:    DefaultHistoryRetention,
+		MaxOrphanChains:     DefaultMaxOrphanChains,
+		MaxOrphansPerSigner: DefaultMaxOrphansPerSigner,
+		OrphanExpiry:        DefaultOrphanExpiry,
+	}
+}
+
+// ParamSetPairs implements the params.ParamSet interface
+func (p *Params) ParamSetPairs() params.ParamSetPairs {
+	return params.ParamSetPairs{
+		{Key: KeyProofAncestorLimit, Value: &p.ProofAncestorLimit},
+		{Key: KeyMaxLookupLimit, Value: &p.MaxLookupLimit},
+		{Key: KeyDefaultLookupLimit, Value: &p.DefaultLookupLimit},
+		{Key: KeySpendsLength, Value: &p.SpendsLength},
+		{Key: KeyMaxPaysLength, Value: &p.MaxPaysLength},
+		{Key: KeyMaxActionLength, Value: &p.MaxActionLength},
+		{Key: KeyForkPruneDepth, Value: &p.ForkPruneDepth},
+		{Key: KeyHistoryRetention, Value: &p.HistoryRetention},
+		{Key: KeyMaxOrphanChains, Value: &p.MaxOrphanChains},
+		{Key: KeyMaxOrphansPerSigner, Value: &p.MaxOrphansPerSigner},
+		{Key: KeyOrphanExpiry, Value: &p.OrphanExpiry},
+	}
+}
+
+// Validate checks that the params are usable
+func (p Params) Validate() error {
+	if p.ProofAncestorLimit == 0 {
+		return errors.New("proof ancestor limit must be positive")
+	}
+	if p.MaxLookupLimit == 0 {
+		return errors.New("max lookup limit must be positive")
+	}
+	if p.DefaultLookupLimit == 0 || p.DefaultLookupLimit > p.MaxLookupLimit {
+		return fmt.Errorf("default lookup limit must be between 1 and the max lookup limit %d", p.MaxLookupLimit)
+	}
+	// Reorgs and retargets must not reach below the retained history
+	if p.HistoryRetention != 0 && p.HistoryRetention < p.MaxLookupLimit {
+		return fmt.Errorf("history retention must be 0 or at least the max lookup limit %d", p.MaxLookupLimit)
+	}
+	if p.MaxOrphanChains != 0 {
+		if p.MaxOrphansPerSigner == 0 || p.MaxOrphansPerSigner > p.MaxOrphanChains {
+			return fmt.Errorf("max orphans per signer must be between 1 and the max orphan chains %d", p.MaxOrphanChains)
+		}
+		if p.OrphanExpiry == 0 {
+			return errors.New("orphan expiry must be positive")
+		}
+	}
+	return nil
+}
+
+// Clamp moves each param that Validate would reject to the nearest usable
+// value. Param change proposals are applied without Validate, so the keeper
+// reads its params through Clamp
+func (p Params) Clamp() Params {
+	if p.ProofAncestorLimit == 0 {
+		p.ProofAncestorLimit = 1
+	}
+	if p.MaxLookupLimit == 0 {
+		p.MaxLookupLimit = 1
+	}
+	if p.DefaultLookupLimit == 0 {
+		p.DefaultLookupLimit = 1
+	}
+	if p.DefaultLookupLimit > p.MaxLookupLimit {
+		p.DefaultLookupLimit = p.MaxLookupLimit
+	}
+	if p.HistoryRetention != 0 && p.HistoryRetention < p.MaxLookupLimit {
+		p.HistoryRetention = p.MaxLookupLimit
+	}
+	if p.MaxOrphanChains != 0 {
+		if p.MaxOrphansPerSigner == 0 {
+			p.MaxOrphansPerSigner = 1
+		}
+		if p.MaxOrphansPerSigner > p.MaxOrphanChains {
+			p.MaxOrphansPerSigner = p.MaxOrphanChains
+		}
+		if p.OrphanExpiry == 0 {
+			p.OrphanExpiry = 1
+		}
+	}
+	return p
+}
+
+// String formats Params
+func (p Params) String() string {
+	return fmt.Sprintf(`Relay Params:
+  Proof Ancestor Limit: %d
+  Max Lookup Limit:     %d
+  Default Lookup Limit: %d
+  Spends Length:        %d
+  Max Pays Length:      %d
+  Max Action Length:    %d
+  Fork Prune Depth:     %d
+  History Retention:    %d
+  Max Orphan Chains:    %d
+  Max Orphans/Signer:   %d
+  Orphan Expiry:        %d`,
+		p.ProofAncestorLimit, p.MaxLookupLimit, p.DefaultLookupLimit,
+		p.SpendsLength, p.MaxPaysLength, p.MaxActionLength,
+		p.ForkPruneDepth, p.HistoryRetention,
+		p.MaxOrphanChains, p.MaxOrphansPerSigner, p.OrphanExpiry)
+}
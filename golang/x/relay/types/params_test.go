@@ -0,0 +1,81 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParamsValidate(t *testing.T) {
+	assert.Nil(t, DefaultParams().Validate())
+
+	params := DefaultParams()
+	params.ProofAncestorLimit = 0
+	assert.NotNil(t, params.Validate())
+
+	params = DefaultParams()
+	params.MaxLookupLimit = 0
+	assert.NotNil(t, params.Validate())
+
+	params = DefaultParams()
+	params.DefaultLookupLimit = 0
+	assert.NotNil(t, params.Validate())
+
+	params = DefaultParams()
+	params.DefaultLookupLimit = params.MaxLookupLimit + 1
+	assert.NotNil(t, params.Validate())
+
+	// history must reach back as far as a lookup
+	params = DefaultParams()
+	params.HistoryRetention = params.MaxLookupLimit
+	assert.Nil(t, params.Validate())
+	params.HistoryRetention = params.MaxLookupLimit - 1
+	assert.NotNil(t, params.Validate())
+
+	params = DefaultParams()
+	params.MaxOrphansPerSigner = 0
+	assert.NotNil(t, params.Validate())
+	params.MaxOrphansPerSigner = params.MaxOrphanChains + 1
+	assert.NotNil(t, params.Validate())
+
+	params = DefaultParams()
+	params.OrphanExpiry = 0
+	assert.NotNil(t, params.Validate())
+
+	// the other orphan params are unused when the pool is disabled
+	params.MaxOrphanChains = 0
+	params.MaxOrphansPerSigner = 0
+	assert.Nil(t, params.Validate())
+}
+
+func TestParamsClamp(t *testing.T) {
+	// valid params are unchanged
+	assert.Equal(t, DefaultParams(), DefaultParams().Clamp())
+
+	params := DefaultParams()
+	params.ProofAncestorLimit = 0
+	params.MaxLookupLimit = 0
+	params.DefaultLookupLimit = 0
+	params.HistoryRetention = 1
+	params.MaxOrphansPerSigner = 0
+	params.OrphanExpiry = 0
+	clamped := params.Clamp()
+	assert.Nil(t, clamped.Validate())
+	assert.Equal(t, uint32(1), clamped.ProofAncestorLimit)
+	assert.Equal(t, uint32(1), clamped.MaxLookupLimit)
+	assert.Equal(t, uint32(1), clamped.DefaultLookupLimit)
+	assert.Equal(t, uint32(1), clamped.HistoryRetention)
+	assert.Equal(t, uint32(1), clamped.MaxOrphansPerSigner)
+	assert.Equal(t, uint32(1), clamped.OrphanExpiry)
+
+	// limits that depend on another are lowered or raised to it
+	params = DefaultParams()
+	params.DefaultLookupLimit = params.MaxLookupLimit + 1
+	params.HistoryRetention = 1
+	params.MaxOrphansPerSigner = params.MaxOrphanChains + 1
+	clamped = params.Clamp()
+	assert.Nil(t, clamped.Validate())
+	assert.Equal(t, params.MaxLookupLimit, clamped.DefaultLookupLimit)
+	assert.Equal(t, params.MaxLookupLimit, clamped.HistoryRetention)
+	assert.Equal(t, params.MaxOrphanChains, clamped.MaxOrphansPerSigner)
+}
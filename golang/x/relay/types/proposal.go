@@ -0,0 +1,86 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+const (
+	// ProposalTypeCheckpoint is the gov proposal type for checkpoint changes
+	ProposalTypeCheckpoint = "RelayCheckpoint"
+)
+
+func init() {
+	govtypes.RegisterProposalType(ProposalTypeCheckpoint)
+	govtypes.RegisterProposalTypeCodec(CheckpointProposal{}, "relay/CheckpointProposal")
+}
+
+// CheckpointProposal is a gov proposal to change the relay's checkpoints.
+// Remove is applied before Add. If RaiseGenesis is non-zero the relay
+// genesis is raised to the checkpoint at that height once the changes apply
+type CheckpointProposal struct {
+	Title        string       `json:"title"`
+	Description  string       `json:"description"`
+	Add          []Checkpoint `json:"add"`
+	Remove       []uint32     `json:"remove"`
+	RaiseGenesis uint32       `json:"raiseGenesis"`
+}
+
+var _ govtypes.Content = CheckpointProposal{}
+
+// NewCheckpointProposal instantiates a CheckpointProposal
+func NewCheckpointProposal(title, description string, add []Checkpoint, remove []uint32, raiseGenesis uint32) CheckpointProposal {
+	return CheckpointProposal{title, description, add, remove, raiseGenesis}
+}
+
+// GetTitle returns the title of the proposal
+func (p CheckpointProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the description of the proposal
+func (p CheckpointProposal) GetDescription() string { return p.Description }
+
+// ProposalRoute returns the route key
+func (p CheckpointProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of the proposal
+func (p CheckpointProposal) ProposalType() string { return ProposalTypeCheckpoint }
+
+// ValidateBasic runs stateless validation
+func (p CheckpointProposal) ValidateBasic() sdk.Error {
+	err := govtypes.ValidateAbstract(DefaultCodespace, p)
+	if err != nil {
+		return err
+	}
+
+	if len(p.Add) == 0 && len(p.Remove) == 0 && p.RaiseGenesis == 0 {
+		return ErrEmptyCheckpointProposal(DefaultCodespace)
+	}
+
+	added := make(map[uint32]bool)
+	for _, c := range p.Add {
+		if added[c.Height] {
+			return ErrDuplicateCheckpoint(DefaultCodespace, c.Height)
+		}
+		added[c.Height] = true
+	}
+	return nil
+}
+
+// String formats a CheckpointProposal
+func (p CheckpointProposal) String() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(`Relay Checkpoint Proposal:
+  Title:         %s
+  Description:   %s
+  Raise Genesis: %d
+  Add:
+`, p.Title, p.Description, p.RaiseGenesis))
+	for _, c := range p.Add {
+		b.WriteString(fmt.Sprintf("    %s\n", c))
+	}
+	b.WriteString(fmt.Sprintf("  Remove: %v", p.Remove))
+	return b.String()
+}
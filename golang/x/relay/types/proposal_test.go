@@ -0,0 +1,29 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckpointProposalValidateBasic(t *testing.T) {
+	checkpoint := Checkpoint{Height: 606210}
+
+	p := NewCheckpointProposal("title", "description", []Checkpoint{checkpoint}, nil, 0)
+	assert.Nil(t, p.ValidateBasic())
+	assert.Equal(t, RouterKey, p.ProposalRoute())
+	assert.Equal(t, ProposalTypeCheckpoint, p.ProposalType())
+
+	p = NewCheckpointProposal("title", "description", nil, nil, 606210)
+	assert.Nil(t, p.ValidateBasic())
+
+	p = NewCheckpointProposal("", "description", []Checkpoint{checkpoint}, nil, 0)
+	assert.NotNil(t, p.ValidateBasic())
+
+	p = NewCheckpointProposal("title", "description", nil, nil, 0)
+	assert.Equal(t, sdk.CodeType(EmptyCheckpointProposal), p.ValidateBasic().Code())
+
+	p = NewCheckpointProposal("title", "description", []Checkpoint{checkpoint, checkpoint}, nil, 0)
+	assert.Equal(t, sdk.CodeType(DuplicateCheckpoint), p.ValidateBasic().Code())
+}
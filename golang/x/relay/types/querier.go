@@ -4,12 +4,11 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 const (
-	// DefaultLookupLimit is the default limit for lookup requests
-	DefaultLookupLimit = 18
-
 	// QueryIsAncestor is a query string tag for IsAncestor
 	QueryIsAncestor = "isancestor"
 
@@ -34,11 +33,53 @@ const (
 	// QueryGetRequest is a query string tag for getRequest
 	QueryGetRequest = "getrequest"
 
+	// QueryGetProvenTx is a query string tag for GetProvenTx
+	QueryGetProvenTx = "getproventx"
+
 	// QueryCheckRequests is a query string tag for checkRequests
 	QueryCheckRequests = "checkrequests"
 
 	// QueryCheckProof is a query string tag for checkProof
 	QueryCheckProof = "checkproof"
+
+	// QueryGetHeader is a query string tag for GetHeader
+	QueryGetHeader = "getheader"
+
+	// QueryGetHeaderByHeight is a query string tag for GetHeaderByHeight
+	QueryGetHeaderByHeight = "getheaderbyheight"
+
+	// QueryGetChainWork is a query string tag for GetChainWork
+	QueryGetChainWork = "getchainwork"
+
+	// QueryGetCurrentEpochDifficulty is a query string tag for GetCurrentEpochDifficulty
+	QueryGetCurrentEpochDifficulty = "getcurrentepochdifficulty"
+
+	// QueryGetPrevEpochDifficulty is a query string tag for GetPrevEpochDifficulty
+	QueryGetPrevEpochDifficulty = "getprevepochdifficulty"
+
+	// QueryFindHeight is a query string tag for FindHeight
+	QueryFindHeight = "findheight"
+
+	// QueryGetChainParams is a query string tag for GetChainParams
+	QueryGetChainParams = "getchainparams"
+
+	// QueryGetTips is a query string tag for GetTips
+	QueryGetTips = "gettips"
+
+	// QueryGetMMRRoot is a query string tag for GetMMRRoot
+	QueryGetMMRRoot = "getmmrroot"
+
+	// QueryGetMMRProof is a query string tag for GetMMRProof
+	QueryGetMMRProof = "getmmrproof"
+
+	// QueryGetHeaders is a query string tag for GetHeaders
+	QueryGetHeaders = "getheaders"
+
+	// QueryLocateHeaders is a query string tag for LocateHeaders
+	QueryLocateHeaders = "locateheaders"
+
+	// QueryParams is a query string tag for Params
+	QueryParams = "params"
 )
 
 // QueryParamsIsAncestor represents the parameters for an IsAncestor query
@@ -176,6 +217,7 @@ type QueryParamsGetRequest struct {
 type QueryResGetRequest struct {
 	Params QueryParamsGetRequest `json:"params"`
 	Res    ProofRequest          `json:"result"`
+	Fills  []Fill                `json:"fills"`
 }
 
 // String formats a QueryResIsMostRecentCommonAncestor struct
@@ -183,8 +225,34 @@ func (r QueryResGetRequest) String() string {
 	spends := "0x" + hex.EncodeToString(r.Res.Spends[:])
 	pays := "0x" + hex.EncodeToString(r.Res.Pays[:])
 	return fmt.Sprintf(
-		"ID: %d, Spends: %s, Pays: %s, Value: %d, Active: %t, Confirmations: %d",
-		r.Params.ID, spends, pays, r.Res.PaysValue, r.Res.ActiveState, r.Res.NumConfs)
+		"ID: %d, Spends: %s, Pays: %s, Value: %d, Active: %t, Confirmations: %d, Fills: %d/%d, Expiry Height: %d, Bitcoin Expiry Height: %d, Bounty: %s, Escrow: %s",
+		r.Params.ID, spends, pays, r.Res.PaysValue, r.Res.ActiveState, r.Res.NumConfs, r.Res.NumFills, r.Res.MaxFills,
+		r.Res.ExpiryHeight, r.Res.BTCExpiryHeight, r.Res.Bounty, r.Res.Escrow)
+}
+
+// QueryParamsGetProvenTx is the params struct for queryGetProvenTx
+type QueryParamsGetProvenTx struct {
+	TxID Hash256Digest `json:"txid"`
+}
+
+// QueryResGetProvenTx is the response struct for queryGetProvenTx
+type QueryResGetProvenTx struct {
+	Params QueryParamsGetProvenTx `json:"params"`
+	Res    ProvenTx               `json:"result"`
+}
+
+// String formats a QueryResGetProvenTx struct
+func (r QueryResGetProvenTx) String() string {
+	txid := "0x" + hex.EncodeToString(r.Params.TxID[:])
+	confirming := "0x" + hex.EncodeToString(r.Res.ConfirmingDigest[:])
+	if r.Res.Pruned {
+		return fmt.Sprintf(
+			"TXID: %s, Confirming Digest: %s (pruned), Requests Filled: %d",
+			txid, confirming, len(r.Res.Requests))
+	}
+	return fmt.Sprintf(
+		"TXID: %s, Confirming Digest: %s, Height: %d, Requests Filled: %d",
+		txid, confirming, r.Res.ConfirmingHeader.Height, len(r.Res.Requests))
 }
 
 // QueryParamsCheckRequests is the response struct for queryCheckRequests
@@ -222,3 +290,203 @@ func (r QueryResCheckProof) String() string {
 	json, _ := json.Marshal(r)
 	return string(json)
 }
+
+// QueryParamsGetHeader is the params struct for queryGetHeader
+type QueryParamsGetHeader struct {
+	DigestLE Hash256Digest `json:"digestLE"`
+}
+
+// QueryResGetHeader is the response struct for queryGetHeader
+type QueryResGetHeader struct {
+	Params QueryParamsGetHeader `json:"params"`
+	Res    BitcoinHeader        `json:"result"`
+}
+
+// String formats a QueryResGetHeader struct
+func (r QueryResGetHeader) String() string {
+	dig := "0x" + hex.EncodeToString(r.Params.DigestLE[:])
+	raw := "0x" + hex.EncodeToString(r.Res.Raw[:])
+	return fmt.Sprintf(
+		"Digest LE: %s, Height: %d, Raw: %s",
+		dig, r.Res.Height, raw)
+}
+
+// QueryParamsGetHeaderByHeight is the params struct for queryGetHeaderByHeight
+type QueryParamsGetHeaderByHeight struct {
+	Height uint32 `json:"height"`
+}
+
+// QueryResGetHeaderByHeight is the response struct for queryGetHeaderByHeight
+type QueryResGetHeaderByHeight struct {
+	Params QueryParamsGetHeaderByHeight `json:"params"`
+	Res    BitcoinHeader                `json:"result"`
+}
+
+// String formats a QueryResGetHeaderByHeight struct
+func (r QueryResGetHeaderByHeight) String() string {
+	dig := "0x" + hex.EncodeToString(r.Res.Hash[:])
+	raw := "0x" + hex.EncodeToString(r.Res.Raw[:])
+	return fmt.Sprintf(
+		"Height: %d, Digest LE: %s, Raw: %s",
+		r.Params.Height, dig, raw)
+}
+
+// QueryParamsGetChainWork is the params struct for queryGetChainWork
+type QueryParamsGetChainWork struct {
+	DigestLE Hash256Digest `json:"digestLE"`
+}
+
+// QueryResGetChainWork is the response struct for queryGetChainWork
+type QueryResGetChainWork struct {
+	Params QueryParamsGetChainWork `json:"params"`
+	Res    sdk.Uint                `json:"result"`
+}
+
+// String formats a QueryResGetChainWork struct
+func (r QueryResGetChainWork) String() string {
+	dig := "0x" + hex.EncodeToString(r.Params.DigestLE[:])
+	return fmt.Sprintf("Digest LE: %s, Chain Work: %s", dig, r.Res)
+}
+
+// QueryResGetCurrentEpochDifficulty is the response struct for queryGetCurrentEpochDifficulty
+type QueryResGetCurrentEpochDifficulty struct {
+	Res sdk.Uint `json:"result"`
+}
+
+// String formats a QueryResGetCurrentEpochDifficulty struct
+func (r QueryResGetCurrentEpochDifficulty) String() string {
+	return fmt.Sprintf("Current Epoch Difficulty: %s", r.Res)
+}
+
+// QueryResGetPrevEpochDifficulty is the response struct for queryGetPrevEpochDifficulty
+type QueryResGetPrevEpochDifficulty struct {
+	Res sdk.Uint `json:"result"`
+}
+
+// String formats a QueryResGetPrevEpochDifficulty struct
+func (r QueryResGetPrevEpochDifficulty) String() string {
+	return fmt.Sprintf("Previous Epoch Difficulty: %s", r.Res)
+}
+
+// QueryParamsFindHeight is the params struct for queryFindHeight
+type QueryParamsFindHeight struct {
+	DigestLE Hash256Digest `json:"digestLE"`
+}
+
+// QueryResFindHeight is the response struct for queryFindHeight
+type QueryResFindHeight struct {
+	Params QueryParamsFindHeight `json:"params"`
+	Res    uint32                `json:"result"`
+}
+
+// String formats a QueryResFindHeight struct
+func (r QueryResFindHeight) String() string {
+	dig := "0x" + hex.EncodeToString(r.Params.DigestLE[:])
+	return fmt.Sprintf("Digest LE: %s, Height: %d", dig, r.Res)
+}
+
+// QueryResGetChainParams is the response struct for queryGetChainParams
+type QueryResGetChainParams struct {
+	Res ChainParams `json:"result"`
+}
+
+// String formats a QueryResGetChainParams struct
+func (r QueryResGetChainParams) String() string {
+	json, _ := json.Marshal(r)
+	return string(json)
+}
+
+// QueryResParams is the response struct for queryParams
+type QueryResParams struct {
+	Res Params `json:"result"`
+}
+
+// String formats a QueryResParams struct
+func (r QueryResParams) String() string {
+	json, _ := json.Marshal(r)
+	return string(json)
+}
+
+// QueryResGetTips is the response struct for queryGetTips
+type QueryResGetTips struct {
+	Res []ChainTip `json:"result"`
+}
+
+// String formats a QueryResGetTips struct
+func (r QueryResGetTips) String() string {
+	json, _ := json.Marshal(r)
+	return string(json)
+}
+
+// QueryResGetMMRRoot is the response struct for queryGetMMRRoot
+type QueryResGetMMRRoot struct {
+	Res MMRRoot `json:"result"`
+}
+
+// String formats a QueryResGetMMRRoot struct
+func (r QueryResGetMMRRoot) String() string {
+	return r.Res.String()
+}
+
+// QueryParamsGetMMRProof is the params struct for queryGetMMRProof
+type QueryParamsGetMMRProof struct {
+	Height uint32 `json:"height"`
+}
+
+// QueryResGetMMRProof is the response struct for queryGetMMRProof
+type QueryResGetMMRProof struct {
+	Params QueryParamsGetMMRProof `json:"params"`
+	Res    MMRProof               `json:"result"`
+}
+
+// String formats a QueryResGetMMRProof struct
+func (r QueryResGetMMRProof) String() string {
+	json, _ := json.Marshal(r)
+	return string(json)
+}
+
+// QueryParamsGetHeaders is the params struct for queryGetHeaders. The range
+// starts at DigestLE if it is set, and at Height otherwise
+type QueryParamsGetHeaders struct {
+	DigestLE Hash256Digest `json:"digest"`
+	Height   uint32        `json:"height"`
+	Count    uint32        `json:"count"`
+}
+
+// QueryResGetHeaders is the response struct for queryGetHeaders
+type QueryResGetHeaders struct {
+	Params QueryParamsGetHeaders `json:"params"`
+	Res    []BitcoinHeader       `json:"result"`
+}
+
+// String formats a QueryResGetHeaders struct
+func (r QueryResGetHeaders) String() string {
+	json, _ := json.Marshal(r)
+	return string(json)
+}
+
+// LocatedHeaders is the answer to a block locator: the highest locator digest
+// on the best chain, and the best chain headers after it
+type LocatedHeaders struct {
+	Fork    BitcoinHeader   `json:"fork"`
+	Headers []BitcoinHeader `json:"headers"`
+}
+
+// QueryParamsLocateHeaders is the params struct for queryLocateHeaders. The
+// locator lists digests from the caller's tip back, with growing gaps
+type QueryParamsLocateHeaders struct {
+	Locator []Hash256Digest `json:"locator"`
+	Count   uint32          `json:"count"`
+}
+
+// QueryResLocateHeaders is the response struct for queryLocateHeaders
+type QueryResLocateHeaders struct {
+	Params QueryParamsLocateHeaders `json:"params"`
+	Res    LocatedHeaders           `json:"result"`
+}
+
+// String formats a QueryResLocateHeaders struct
+func (r QueryResLocateHeaders) String() string {
+	json, _ := json.Marshal(r)
+	return string(json)
+}
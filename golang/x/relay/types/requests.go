@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
+	"math/big"
 	"strconv"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -13,15 +14,80 @@ import (
 // RequestID is an 8 byte id used to store requests
 type RequestID [8]byte
 
-// ProofRequest is info about a proof request
+// ProofRequest is info about a proof request. A MaxFills of 0 allows any
+// number of fills. Only requests stored before fill limits have it
 type ProofRequest struct {
-	Spends      Hash256Digest `json:"spends"`
-	Pays        Hash256Digest `json:"pays"`
-	PaysValue   uint64        `json:"paysValue"`
-	ActiveState bool          `json:"activeState"`
-	NumConfs    uint8         `json:"numConfs"`
-	Origin      Origin        `json:"origin"`
-	Action      HexBytes      `json:"action"`
+	Spends      Hash256Digest  `json:"spends"`
+	Pays        Hash256Digest  `json:"pays"`
+	PaysValue   uint64         `json:"paysValue"`
+	ActiveState bool           `json:"activeState"`
+	NumConfs    uint8          `json:"numConfs"`
+	Origin      Origin         `json:"origin"`
+	Action      HexBytes       `json:"action"`
+	Creator     sdk.AccAddress `json:"creator"`
+	MaxFills    uint32         `json:"maxFills"`
+	NumFills    uint32         `json:"numFills"`
+	// Requests expire at the end of the Cosmos block at ExpiryHeight, and
+	// once the relay's best chain is above BTCExpiryHeight. 0 is no expiry
+	ExpiryHeight    int64  `json:"expiryHeight"`
+	BTCExpiryHeight uint32 `json:"btcExpiryHeight"`
+	// Bounty is paid to the prover for each fill, out of the Escrow held by
+	// the module account. The rest of the Escrow is refunded when the request
+	// is cancelled or expires
+	Bounty sdk.Coins `json:"bounty"`
+	Escrow sdk.Coins `json:"escrow"`
+}
+
+// RequestOptions are the optional settings of a new request. The zero value
+// allows one fill, never expires, and pays no bounty
+type RequestOptions struct {
+	MaxFills        uint32    `json:"maxFills"`
+	ExpiryHeight    int64     `json:"expiryHeight"`
+	BTCExpiryHeight uint32    `json:"btcExpiryHeight"`
+	Bounty          sdk.Coins `json:"bounty"`
+}
+
+// Fills returns the number of fills the request allows
+func (o RequestOptions) Fills() uint32 {
+	// Requests are one-shot unless they ask for more fills
+	if o.MaxFills == 0 {
+		return 1
+	}
+	return o.MaxFills
+}
+
+// maxIntBits is the length of the largest sdk.Int. Longer results panic
+const maxIntBits = 255
+
+// escrowFits checks that the bounty can be multiplied by the number of fills
+// without overflowing an sdk.Int
+func escrowFits(bounty sdk.Coins, fills uint32) bool {
+	n := new(big.Int).SetUint64(uint64(fills))
+	for _, coin := range bounty {
+		if new(big.Int).Mul(coin.Amount.BigInt(), n).BitLen() > maxIntBits {
+			return false
+		}
+	}
+	return true
+}
+
+// Fill records the transaction that filled a request, and the block that
+// confirmed it. The fill is undone if that block leaves the best chain
+type Fill struct {
+	ID               RequestID     `json:"id"`
+	TxID             Hash256Digest `json:"txid"`
+	ConfirmingDigest Hash256Digest `json:"confirmingDigest"`
+}
+
+// ProvenTx is a transaction that has filled requests, along with the block
+// that confirmed it. Once that block has been pruned, only its digest is
+// known and the header is left empty
+type ProvenTx struct {
+	TxID             Hash256Digest       `json:"txid"`
+	ConfirmingDigest Hash256Digest       `json:"confirmingDigest"`
+	ConfirmingHeader BitcoinHeader       `json:"confirmingHeader"`
+	Pruned           bool                `json:"pruned"`
+	Requests         []IdentifiedRequest `json:"requests"`
 }
 
 // NewRequestID instantiates a RequestID from a byte slice
@@ -42,7 +108,7 @@ func RequestIDFromString(s string) (RequestID, error) {
 	var idBytes []byte
 	var err error
 
-	if s[:2] == "0x" {
+	if len(s) >= 2 && s[:2] == "0x" {
 		idBytes, err = hex.DecodeString(s[2:])
 		if err != nil {
 			return RequestID{}, ErrBadHex(DefaultCodespace, s)
@@ -54,6 +120,7 @@ func RequestIDFromString(s string) (RequestID, error) {
 		}
 
 		// convert to bytes
+		idBytes = make([]byte, 8)
 		binary.BigEndian.PutUint64(idBytes, id)
 	}
 
@@ -0,0 +1,33 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// TipStatusActive is the status of the best known tip
+	TipStatusActive = "active"
+	// TipStatusValidFork is the status of a tip that is not on the best chain
+	TipStatusValidFork = "valid-fork"
+)
+
+// ChainTip is a header with no known children. ForkPoint is the block where
+// its branch leaves the best chain, and BranchLen is the number of blocks in
+// the branch. The best known tip is its own fork point
+type ChainTip struct {
+	Digest     Hash256Digest `json:"digest"`
+	Height     uint32        `json:"height"`
+	ChainWork  sdk.Uint      `json:"chainWork"`
+	ForkPoint  Hash256Digest `json:"forkPoint"`
+	ForkHeight uint32        `json:"forkHeight"`
+	BranchLen  uint32        `json:"branchLen"`
+	Status     string        `json:"status"`
+}
+
+// String formats a ChainTip
+func (t ChainTip) String() string {
+	return fmt.Sprintf("%s tip %x at height %d, forked at %d (branch length %d)",
+		t.Status, t.Digest, t.Height, t.ForkHeight, t.BranchLen)
+}